@@ -0,0 +1,196 @@
+package gotely
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Client sends requests to the Telegram Bot API on behalf of a single bot.
+// It is created once from the bot token and a set of [RequestOption],
+// and is safe for concurrent use by multiple goroutines.
+//
+// Example:
+//
+//	client := gotely.NewClient("MY-SECRET-TOKEN", gotely.WithClient(myClient))
+//
+//	var msg objects.Message
+//	if err := client.Do(ctx, methods.SendMessage{ChatId: "@cool_username", Text: "hello"}, &msg); err != nil {
+//		// handling error
+//	}
+type Client struct {
+	token  string
+	cfg    RequestConfig
+	invoke Invoker
+}
+
+// NewClient creates a new [Client] using the provided token and optional request options opts.
+func NewClient(token string, opts ...RequestOption) *Client {
+	c := &Client{
+		token: token,
+		cfg:   makeReqCfg(opts...),
+	}
+	c.chain()
+	return c
+}
+
+// chain wraps the client's round trip into the configured interceptors.
+func (c *Client) chain() {
+	c.invoke = c.roundTrip
+	for i := len(c.cfg.Interceptors) - 1; i >= 0; i-- {
+		c.invoke = c.cfg.Interceptors[i](c.invoke)
+	}
+}
+
+// Token returns the Telegram Bot API token used by the client.
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Config returns a copy of the configuration the client was created with.
+func (c *Client) Config() RequestConfig {
+	return c.cfg
+}
+
+// Do sends a request to the Telegram Bot API with parameters described in body.
+// If ctx is nil, the context passed with [WithContext] is used.
+// If dest is not nil, the response content is written to it.
+// Pass nil as dest to ignore the response content.
+func (c *Client) Do(ctx context.Context, body Method, dest any) error {
+	if err := body.Validate(); err != nil {
+		return err
+	}
+	if c.token == "" {
+		return fmt.Errorf("API token can't be empty")
+	}
+	if ctx == nil {
+		ctx = c.cfg.Context
+	}
+
+	err := c.attempt(ctx, body, dest)
+	if err != nil && c.cfg.FollowChatMigration {
+		if next, ok := c.migrate(body, err); ok {
+			return c.attempt(ctx, next, dest)
+		}
+	}
+	return err
+}
+
+// attempt sends a request, retrying it according to the client's [RetryPolicy] if there is one.
+func (c *Client) attempt(ctx context.Context, body Method, dest any) error {
+	if c.cfg.Retry == nil {
+		return c.send(ctx, body, dest)
+	}
+	return c.cfg.Retry.do(ctx, func() error {
+		return c.send(ctx, body, dest)
+	})
+}
+
+// send makes a single attempt to send a request to the Telegram Bot API.
+func (c *Client) send(ctx context.Context, body Method, dest any) error {
+	result, err := c.invoke(ctx, body.Endpoint(), body)
+	if err != nil {
+		return err
+	}
+
+	if !result.Ok {
+		e := ErrTelegramAPIFailedRequest{
+			ResponseParameters: result.Parameters,
+		}
+		if result.ErrorCode != nil {
+			e.Code = *result.ErrorCode
+		}
+		if result.Description != nil {
+			e.Description = *result.Description
+		}
+		return e
+	}
+	// not writing any results if destination is nil
+	// not returning any errors because the request itself was successful
+	if dest == nil {
+		return nil
+	}
+	return json.NewDecoder(bytes.NewReader(result.Result)).Decode(dest)
+}
+
+// roundTrip is the innermost [Invoker] of the client.
+// It sends the HTTP request and decodes the response.
+func (c *Client) roundTrip(ctx context.Context, endpoint string, body Method) (*ApiResponse, error) {
+	if c.cfg.RateLimiter != nil {
+		if err := c.cfg.RateLimiter.Wait(ctx, body); err != nil {
+			return nil, err
+		}
+	}
+
+	url := formatUrl(c.cfg.ApiUrl, c.token, endpoint)
+	// its important to call Reader() before using ContentType()
+	// since content-type boundary is generated inside Reader() and stored inside of a struct
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body.Reader())
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", body.ContentType())
+
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ApiResponse
+	if err := DecodeJSON(resp.Body, &result); err != nil {
+		// some proxies and load balancers respond with a non-JSON body
+		if resp.StatusCode < http.StatusInternalServerError {
+			return nil, err
+		}
+		desc := resp.Status
+		result = ApiResponse{Ok: false, Description: &desc}
+	}
+	if !result.Ok && result.ErrorCode == nil {
+		code := resp.StatusCode
+		result.ErrorCode = &code
+	}
+	return &result, nil
+}
+
+// Returns is embedded into a [Method] to declare the type of its result.
+// It contains no data and doesn't affect the request body.
+//
+// Example:
+//
+//	type SendMessage struct {
+//		gotely.Returns[objects.Message]
+//
+//		ChatId string `json:"chat_id"`
+//		Text   string `json:"text"`
+//	}
+type Returns[R any] struct{}
+
+func (Returns[R]) result(R) {}
+
+// MethodOf is a [Method] which result is of type R.
+// To implement it, embed [Returns] into your method type.
+type MethodOf[R any] interface {
+	Method
+	result(R)
+}
+
+// Call sends a request to the Telegram Bot API using the client c
+// and returns the result of the type declared by body.
+//
+// Example:
+//
+//	msg, err := gotely.Call(ctx, client, methods.SendMessage{
+//		ChatId: "@cool_username",
+//		Text:   "hello",
+//	})
+//	// msg is an objects.Message
+func Call[R any](ctx context.Context, c *Client, body MethodOf[R]) (R, error) {
+	var dest R
+	if err := c.Do(ctx, body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
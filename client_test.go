@@ -0,0 +1,296 @@
+package gotely_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/methods"
+)
+
+type fakeRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func respondWith(body string) *http.Client {
+	return &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+				Body: io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+}
+
+func TestCall(t *testing.T) {
+	c := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(respondWith(`{"ok":true,"result":{"message_id":42,"date":1,"chat":{"id":1,"type":"private"}}}`)))
+
+	msg, err := gotely.Call(context.Background(), c, methods.SendMessage{ChatId: "1", Text: "hello"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if msg.MessageId != 42 {
+		t.Fatalf("expected message_id 42, got %d", msg.MessageId)
+	}
+}
+
+func TestCallMessageOrTrue(t *testing.T) {
+	c := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(respondWith(`{"ok":true,"result":true}`)))
+
+	id := "inline"
+	res, err := gotely.Call(context.Background(), c, methods.EditMessageText{InlineMessageId: &id, Text: "hello"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !res.Ok || res.Message != nil {
+		t.Fatalf("expected True without a message, got %+v", res)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	attempts := 0
+	hc := &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			attempts++
+			body := `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0","parameters":{"retry_after":0}}`
+			if attempts == 3 {
+				body = `{"ok":true,"result":true}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+
+	c := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc))
+	_, err := gotely.Call(context.Background(), c, methods.GetMe{})
+	var apiErr gotely.ErrTelegramAPIFailedRequest
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected API error, got %v", err)
+	}
+	if apiErr.ResponseParameters == nil || apiErr.ResponseParameters.RetryAfter == nil {
+		t.Fatalf("expected retry_after to be propagated, got %+v", apiErr)
+	}
+
+	attempts = 0
+	c = gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc), gotely.WithRetry(gotely.DefaultRetryPolicy))
+	if _, err := gotely.Call(context.Background(), c, methods.LogOut{}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	p := gotely.RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	flood := func(retryAfter int) error {
+		return gotely.ErrTelegramAPIFailedRequest{
+			Code:               http.StatusTooManyRequests,
+			ResponseParameters: &gotely.ResponseParameters{RetryAfter: &retryAfter},
+		}
+	}
+
+	if d, ok := p.Delay(0, flood(30)); !ok || d != 30*time.Second {
+		t.Fatalf("expected to retry after 30s, got %s, %t", d, ok)
+	}
+	if d, ok := p.Delay(0, flood(120)); ok {
+		t.Fatalf("expected not to retry before retry_after exceeding MaxDelay, got %s", d)
+	}
+}
+
+func TestChatMigration(t *testing.T) {
+	var chatIds []string
+	hc := &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			var body struct {
+				ChatId string `json:"chat_id"`
+			}
+			if err := gotely.DecodeJSON(r.Body, &body); err != nil {
+				return nil, err
+			}
+			chatIds = append(chatIds, body.ChatId)
+			resp := `{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":-1001234}}`
+			if body.ChatId == "-1001234" {
+				resp = `{"ok":true,"result":{"message_id":1,"date":1,"chat":{"id":-1001234,"type":"supergroup"}}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(resp)),
+			}, nil
+		}),
+	}
+
+	var from string
+	var to int64
+	c := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc), gotely.WithChatMigration(func(f string, t int64) {
+		from, to = f, t
+	}))
+	sm := methods.SendMessage{ChatId: "-1234", Text: "hello"}
+	msg, err := gotely.Call(context.Background(), c, sm)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if msg.Chat.Id != -1001234 || from != "-1234" || to != -1001234 {
+		t.Fatalf("unexpected migration: chat %d, from %s to %d", msg.Chat.Id, from, to)
+	}
+	if sm.ChatId != "-1234" || len(chatIds) != 2 {
+		t.Fatalf("expected the original body to be unchanged and 2 requests, got %s and %v", sm.ChatId, chatIds)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) gotely.Interceptor {
+		return func(next gotely.Invoker) gotely.Invoker {
+			return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+				calls = append(calls, name+":"+endpoint)
+				return next(ctx, endpoint, body)
+			}
+		}
+	}
+	cached := func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			return &gotely.ApiResponse{Ok: true, Result: []byte(`{"id":1,"first_name":"bot","is_bot":true}`)}, nil
+		}
+	}
+
+	c := gotely.NewClient("MOCK_TOKEN",
+		gotely.WithClient(respondWith(`{"ok":false,"error_code":500,"description":"unreachable"}`)),
+		gotely.WithInterceptors(record("first"), record("second"), cached),
+	)
+	me, err := gotely.Call(context.Background(), c, methods.GetMe{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if me.FirstName != "bot" {
+		t.Fatalf("expected cached response, got %+v", me)
+	}
+	if strings.Join(calls, ",") != "first:getMe,second:getMe" {
+		t.Fatalf("unexpected order of interceptors: %v", calls)
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	var requested string
+	hc := &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			requested = r.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("file contents")),
+			}, nil
+		}),
+	}
+	c := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc), gotely.WithUrl("http://localhost:8081/bot<token>/<method>"))
+
+	var sb strings.Builder
+	if err := c.DownloadFile(context.Background(), "documents/file_1.txt", &sb); err != nil {
+		t.Fatal(err.Error())
+	}
+	if requested != "http://localhost:8081/file/botMOCK_TOKEN/documents/file_1.txt" {
+		t.Fatalf("unexpected file URL: %s", requested)
+	}
+	if sb.String() != "file contents" {
+		t.Fatalf("unexpected file contents: %s", sb.String())
+	}
+}
+
+func TestOpenFileOnlyLocal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file_1.txt")
+	if err := os.WriteFile(path, []byte("file on the disk"), 0o600); err != nil {
+		t.Fatal(err.Error())
+	}
+	var requested int
+	hc := &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			requested++
+			body := "file from the server"
+			if !strings.Contains(r.URL.Path, "/file/") {
+				body = `{"ok":true,"result":true}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+	read := func(c *gotely.Client) string {
+		var sb strings.Builder
+		if err := c.DownloadFile(context.Background(), path, &sb); err != nil {
+			t.Fatal(err.Error())
+		}
+		return sb.String()
+	}
+
+	local := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc),
+		gotely.WithLocalServer("http://localhost:8081/bot<token>/<method>"),
+	)
+	if got := read(local); got != "file on the disk" || requested != 0 {
+		t.Fatalf("expected the local client to read the file from the disk, got %q", got)
+	}
+
+	cloud := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc))
+	if got := read(cloud); got != "file from the server" || requested != 1 {
+		t.Fatalf("expected the cloud client to download the file, got %q", got)
+	}
+
+	moved, err := local.MoveTo(context.Background(), gotely.WithUrl(gotely.DEFAULT_URL_TEMPLATE))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if moved.IsLocal() {
+		t.Fatal("expected the client moved to the cloud server not to be local")
+	}
+	if got := read(moved); got != "file from the server" {
+		t.Fatalf("expected the moved client to download the file, got %q", got)
+	}
+}
+
+func TestMoveToLocalServer(t *testing.T) {
+	var requested []string
+	hc := &http.Client{
+		Transport: fakeRoundTripper(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.String())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":true}`)),
+			}, nil
+		}),
+	}
+	cloud := gotely.NewClient("MOCK_TOKEN", gotely.WithClient(hc))
+
+	local, err := cloud.MoveTo(context.Background(), gotely.WithLocalServer("http://localhost:8081/bot<token>/<method>"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !local.IsLocal() {
+		t.Fatal("expected the new client to work with a local server")
+	}
+	if _, err := local.MoveTo(context.Background(), gotely.WithLocalServer("http://localhost:8082/bot<token>/<method>")); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := []string{
+		"https://api.telegram.org/botMOCK_TOKEN/logOut",
+		"http://localhost:8081/botMOCK_TOKEN/deleteWebhook",
+		"http://localhost:8081/botMOCK_TOKEN/close",
+	}
+	if strings.Join(requested, " ") != strings.Join(expected, " ") {
+		t.Fatalf("unexpected requests: %v", requested)
+	}
+}
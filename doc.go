@@ -2,6 +2,8 @@
 // All requests sent with [SendRequest] or [SendRequestWith] either store the response in the provided destination
 // or return [ErrTelegramAPIFailedRequest], providing details on the failure,
 // or [ErrFailedValidation], if the request body failed validation.
+// A [Client] can be created once and reused for every request, and [Call] returns
+// the result of the type declared by the method with [Returns].
 //
 // Additionally, utility functions are available for working with JSON encoding.
 //
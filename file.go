@@ -0,0 +1,91 @@
+package gotely
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileUrl returns the URL for downloading the file with the given file_path,
+// as returned by [methods.GetFile] in [objects.File].
+// The URL is built from the same template as the one used for API requests,
+// for example "https://api.telegram.org/file/bot<token>/<file_path>" for [DEFAULT_URL_TEMPLATE].
+func (c *Client) FileUrl(filePath string) string {
+	return formatUrl(fileUrlTemplate(c.cfg.ApiUrl), c.token, strings.TrimPrefix(filePath, "/"))
+}
+
+// OpenFile opens the file with the given file_path, as returned by [methods.GetFile] in [objects.File].
+// If the client works with a local Bot API server, which returns absolute file paths,
+// the file is opened directly from the disk.
+// Otherwise, it is downloaded from the Telegram Bot API, even if the path is absolute.
+// It's the caller's responsibility to close the returned [io.ReadCloser].
+//
+// Note that the cloud Bot API server only allows downloading files of up to 20MB in size.
+func (c *Client) OpenFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	if filePath == "" {
+		return nil, fmt.Errorf("file path can't be empty")
+	}
+	if c.token == "" {
+		return nil, fmt.Errorf("API token can't be empty")
+	}
+	if ctx == nil {
+		ctx = c.cfg.Context
+	}
+	if c.IsLocal() && filepath.IsAbs(filePath) {
+		return os.Open(c.localPath(filePath))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.FileUrl(filePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		e := ErrTelegramAPIFailedRequest{
+			Code:        resp.StatusCode,
+			Description: resp.Status,
+		}
+		var result ApiResponse
+		if err := DecodeJSON(resp.Body, &result); err == nil && result.Description != nil {
+			e.Description = *result.Description
+		}
+		return nil, e
+	}
+	return resp.Body, nil
+}
+
+// DownloadFile writes the contents of the file with the given file_path,
+// as returned by [methods.GetFile] in [objects.File], to w.
+// See [Client.OpenFile] for details.
+func (c *Client) DownloadFile(ctx context.Context, filePath string, w io.Writer) error {
+	f, err := c.OpenFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// fileUrlTemplate turns an API URL template into a template for downloading files,
+// with <method> being replaced by the file path.
+// For example, "https://api.telegram.org/bot<token>/<method>"
+// becomes "https://api.telegram.org/file/bot<token>/<method>".
+func fileUrlTemplate(template string) string {
+	i := strings.Index(template, "<token>")
+	if i < 0 {
+		return template
+	}
+	// inserting "file/" before the path segment containing the token
+	i = strings.LastIndex(template[:i], "/") + 1
+	return template[:i] + "file/" + template[i:]
+}
@@ -0,0 +1,180 @@
+package format
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+// Builder builds a formatted text, keeping track of the offsets of its entities in UTF-16 code units.
+//
+// Example:
+//
+//	b := format.New().
+//		Bold("Hello").
+//		Text(", ").
+//		TextMention(user.FirstName, user).
+//		Text("!\n").
+//		Pre("fmt.Println(42)", "go")
+//
+//	msg := methods.SendMessage{ChatId: chatId, Text: b.String()}
+//	entities := b.Entities()
+//	msg.Entities = &entities
+//
+// or, with a parse mode:
+//
+//	mode := "HTML"
+//	msg := methods.SendMessage{ChatId: chatId, Text: b.HTML(), ParseMode: &mode}
+//
+// The zero value is an empty text ready to use.
+type Builder struct {
+	text     strings.Builder
+	length   int
+	entities []objects.MessageEntity
+}
+
+// New creates a new empty [Builder].
+func New() *Builder {
+	return &Builder{}
+}
+
+// Text appends s without formatting.
+func (b *Builder) Text(s string) *Builder {
+	b.text.WriteString(s)
+	b.length += objects.UTF16Len(s)
+	return b
+}
+
+// Textf appends the text formatted with [fmt.Sprintf] without formatting.
+func (b *Builder) Textf(format string, args ...any) *Builder {
+	return b.Text(fmt.Sprintf(format, args...))
+}
+
+// Bold appends s as bold text.
+func (b *Builder) Bold(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "bold"})
+}
+
+// Italic appends s as italic text.
+func (b *Builder) Italic(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "italic"})
+}
+
+// Underline appends s as underlined text.
+func (b *Builder) Underline(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "underline"})
+}
+
+// Strikethrough appends s as strikethrough text.
+func (b *Builder) Strikethrough(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "strikethrough"})
+}
+
+// Spoiler appends s hidden as a spoiler.
+func (b *Builder) Spoiler(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "spoiler"})
+}
+
+// Code appends s as inline monowidth code.
+func (b *Builder) Code(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "code"})
+}
+
+// Pre appends s as a monowidth block of code in the programming language,
+// which can be empty.
+func (b *Builder) Pre(s, language string) *Builder {
+	e := objects.MessageEntity{Type: "pre"}
+	if language != "" {
+		e.Language = &language
+	}
+	return b.entity(s, e)
+}
+
+// TextLink appends s as a link to url.
+func (b *Builder) TextLink(s, url string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "text_link", Url: &url})
+}
+
+// TextMention appends s as a mention of user, which works for the users without a username.
+func (b *Builder) TextMention(s string, user objects.User) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "text_mention", User: &user})
+}
+
+// CustomEmoji appends the custom emoji with the id, shown as emoji where custom emoji are not supported.
+func (b *Builder) CustomEmoji(emoji, id string) *Builder {
+	return b.entity(emoji, objects.MessageEntity{Type: "custom_emoji", CustomEmojiId: &id})
+}
+
+// Blockquote appends s as a block quotation.
+// A block quotation must start at the beginning of a line.
+func (b *Builder) Blockquote(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "blockquote"})
+}
+
+// ExpandableBlockquote appends s as a block quotation collapsed by default.
+// A block quotation must start at the beginning of a line.
+func (b *Builder) ExpandableBlockquote(s string) *Builder {
+	return b.entity(s, objects.MessageEntity{Type: "expandable_blockquote"})
+}
+
+// Append appends the text of other with its entities.
+func (b *Builder) Append(other *Builder) *Builder {
+	for _, e := range other.entities {
+		e.Offset += b.length
+		b.entities = append(b.entities, e)
+	}
+	return b.Text(other.text.String())
+}
+
+// Wrap appends the text of other with its entities, formatted as a whole with entity e,
+// which Offset and Length are set by Wrap. It can be used for nested formatting:
+//
+//	inner := format.New().Bold("Note:").Text(" formatting can be nested")
+//	b.Wrap(objects.MessageEntity{Type: "blockquote"}, inner)
+func (b *Builder) Wrap(e objects.MessageEntity, other *Builder) *Builder {
+	e.Offset, e.Length = b.length, other.length
+	// added before the inner entities, so it stays the outer one when they have the same range
+	if e.Length > 0 {
+		b.entities = append(b.entities, e)
+	}
+	return b.Append(other)
+}
+
+func (b *Builder) entity(s string, e objects.MessageEntity) *Builder {
+	e.Offset, e.Length = b.length, objects.UTF16Len(s)
+	b.Text(s)
+	if e.Length > 0 {
+		b.entities = append(b.entities, e)
+	}
+	return b
+}
+
+// Len returns the length of the text in UTF-16 code units, as counted by the Telegram Bot API.
+func (b *Builder) Len() int {
+	return b.length
+}
+
+// String returns the text without formatting.
+func (b *Builder) String() string {
+	return b.text.String()
+}
+
+// Entities returns the entities of the text, sorted by their offsets,
+// with the outer ones first if several start at the same offset.
+func (b *Builder) Entities() []objects.MessageEntity {
+	entities := slices.Clone(b.entities)
+	sortEntities(entities)
+	return entities
+}
+
+// HTML returns the text formatted for the "HTML" parse mode.
+func (b *Builder) HTML() string {
+	return HTML(b.String(), b.entities)
+}
+
+// MarkdownV2 returns the text formatted for the "MarkdownV2" parse mode.
+func (b *Builder) MarkdownV2() string {
+	return MarkdownV2(b.String(), b.entities)
+}
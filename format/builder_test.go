@@ -0,0 +1,47 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/objects"
+)
+
+func TestBuilder(t *testing.T) {
+	b := format.New().
+		Text("👋 ").
+		Bold("Hi").
+		Text(", ").
+		TextMention("Ann", objects.User{Id: 42}).
+		Text("! 1+1=2 <ok>\n").
+		Pre("fmt.Println(`a`)", "go").
+		Text("\n").
+		Wrap(objects.MessageEntity{Type: "blockquote"}, format.New().Italic("quote").Text("\nsecond")).
+		Text("\n").
+		Wrap(objects.MessageEntity{Type: "underline"}, format.New().Italic("both"))
+
+	entities := b.Entities()
+	// the emoji takes two UTF-16 code units
+	if entities[0].Type != "bold" || entities[0].Offset != 3 || entities[0].Length != 2 {
+		t.Fatalf("unexpected first entity: %+v", entities[0])
+	}
+	if b.Len() != len([]rune(b.String()))+1 {
+		t.Fatalf("unexpected length %d", b.Len())
+	}
+
+	html := "👋 <b>Hi</b>, <a href=\"tg://user?id=42\">Ann</a>! 1+1=2 &lt;ok&gt;\n" +
+		"<pre><code class=\"language-go\">fmt.Println(`a`)</code></pre>\n" +
+		"<blockquote><i>quote</i>\nsecond</blockquote>\n" +
+		"<u><i>both</i></u>"
+	if got := b.HTML(); got != html {
+		t.Fatalf("unexpected HTML:\n%s\nexpected:\n%s", got, html)
+	}
+
+	md := "👋 *Hi*, [Ann](tg://user?id=42)\\! 1\\+1\\=2 <ok\\>\n" +
+		"```go\nfmt.Println(\\`a\\`)```\n" +
+		">_quote_\n>second\n" +
+		"___both_**__"
+	if got := b.MarkdownV2(); got != md {
+		t.Fatalf("unexpected MarkdownV2:\n%s\nexpected:\n%s", got, md)
+	}
+}
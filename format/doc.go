@@ -0,0 +1,5 @@
+// This package provides a builder for formatted texts, rendering them either
+// as plain text with [objects.MessageEntity], or as HTML or MarkdownV2 with every special character escaped.
+//
+// Licensed under the MIT License. See LICENSE file for details.
+package format
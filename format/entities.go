@@ -0,0 +1,120 @@
+package format
+
+import (
+	"iter"
+	"slices"
+	"unicode/utf16"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+// Len returns the length of s in UTF-16 code units, as counted by the Telegram Bot API
+// for the offsets and lengths of entities and the limits of texts.
+func Len(s string) int {
+	return objects.UTF16Len(s)
+}
+
+// Index returns the byte index of s that is n UTF-16 code units from its start.
+// It reports false if s is shorter, or the index is inside a surrogate pair.
+func Index(s string, n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	units := 0
+	for i, r := range s {
+		if units == n {
+			return i, true
+		}
+		if units > n {
+			return 0, false
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(s), units == n
+}
+
+// Slice returns the part of s starting at offset and having length, both in UTF-16 code units.
+// It reports false if the part is out of s or splits a surrogate pair.
+func Slice(s string, offset, length int) (string, bool) {
+	start, ok := Index(s, offset)
+	if !ok || length < 0 {
+		return "", false
+	}
+	n, ok := Index(s[start:], length)
+	if !ok {
+		return "", false
+	}
+	return s[start : start+n], true
+}
+
+// EntityText returns the part of text covered by e, like the URL of a "url" entity,
+// or an empty string if e is out of text.
+func EntityText(text string, e objects.MessageEntity) string {
+	s, _ := Slice(text, e.Offset, e.Length)
+	return s
+}
+
+// textOf returns the text or the caption of msg with its entities.
+func textOf(msg objects.Message) (string, []objects.MessageEntity) {
+	if msg.Text != nil {
+		if msg.Entities != nil {
+			return *msg.Text, *msg.Entities
+		}
+		return *msg.Text, nil
+	}
+	if msg.Caption != nil {
+		if msg.CaptionEntities != nil {
+			return *msg.Caption, *msg.CaptionEntities
+		}
+		return *msg.Caption, nil
+	}
+	return "", nil
+}
+
+// EntitiesOf iterates over the entities of the text or the caption of msg with the text they cover.
+// If types are given, only the entities of these types are yielded, for example:
+//
+//	for e, tag := range format.EntitiesOf(msg, "hashtag", "cashtag") {
+//		// handling tags
+//	}
+func EntitiesOf(msg objects.Message, types ...string) iter.Seq2[objects.MessageEntity, string] {
+	text, entities := textOf(msg)
+	return func(yield func(objects.MessageEntity, string) bool) {
+		for _, e := range entities {
+			if len(types) > 0 && !slices.Contains(types, e.Type) {
+				continue
+			}
+			if !yield(e, EntityText(text, e)) {
+				return
+			}
+		}
+	}
+}
+
+// HTML returns text with entities formatted for the "HTML" parse mode.
+// The entities can be in any order, and the ones without formatting, like "mention" or "url", are ignored.
+func HTML(text string, entities []objects.MessageEntity) string {
+	entities = slices.Clone(entities)
+	sortEntities(entities)
+	return render(text, entities, &htmlMarkup{})
+}
+
+// MarkdownV2 returns text with entities formatted for the "MarkdownV2" parse mode.
+// The entities can be in any order, and the ones without formatting, like "mention" or "url", are ignored.
+func MarkdownV2(text string, entities []objects.MessageEntity) string {
+	entities = slices.Clone(entities)
+	sortEntities(entities)
+	return render(text, entities, &markdownMarkup{})
+}
+
+// MessageHTML returns the text or the caption of msg formatted for the "HTML" parse mode,
+// so it can be sent again with the same formatting.
+func MessageHTML(msg objects.Message) string {
+	return HTML(textOf(msg))
+}
+
+// MessageMarkdownV2 returns the text or the caption of msg formatted for the "MarkdownV2" parse mode,
+// so it can be sent again with the same formatting.
+func MessageMarkdownV2(msg objects.Message) string {
+	return MarkdownV2(textOf(msg))
+}
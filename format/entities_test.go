@@ -0,0 +1,48 @@
+package format_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/objects"
+)
+
+func TestEntities(t *testing.T) {
+	text := "🎉 #go and #telegram: https://t.me 😀"
+	msg := objects.Message{
+		Text: &text,
+		Entities: &[]objects.MessageEntity{
+			{Type: "url", Offset: 22, Length: 12},
+			{Type: "hashtag", Offset: 3, Length: 3},
+			{Type: "italic", Offset: 0, Length: 20},
+			{Type: "bold", Offset: 11, Length: 9},
+			{Type: "hashtag", Offset: 11, Length: 9},
+		},
+	}
+
+	var tags []string
+	for _, tag := range format.EntitiesOf(msg, "hashtag") {
+		tags = append(tags, tag)
+	}
+	if !slices.Equal(tags, []string{"#go", "#telegram"}) {
+		t.Fatalf("unexpected hashtags: %v", tags)
+	}
+	for _, url := range format.EntitiesOf(msg, "url") {
+		if url != "https://t.me" {
+			t.Fatalf("unexpected url: %q", url)
+		}
+	}
+
+	// the offset is inside the surrogate pair of the emoji
+	if _, ok := format.Slice(text, 1, 2); ok {
+		t.Fatal("expected slicing inside a surrogate pair to fail")
+	}
+
+	if got := format.MessageHTML(msg); got != "<i>🎉 #go and <b>#telegram</b></i>: https://t.me 😀" {
+		t.Fatalf("unexpected HTML: %s", got)
+	}
+	if got := format.MessageMarkdownV2(msg); got != `_🎉 \#go and *\#telegram*_: https://t\.me 😀` {
+		t.Fatalf("unexpected MarkdownV2: %s", got)
+	}
+}
@@ -0,0 +1,301 @@
+package format
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+// sortEntities sorts entities by their offsets, placing the outer ones first.
+func sortEntities(entities []objects.MessageEntity) {
+	slices.SortStableFunc(entities, func(a, b objects.MessageEntity) int {
+		return cmp.Or(cmp.Compare(a.Offset, b.Offset), cmp.Compare(b.Length, a.Length))
+	})
+}
+
+// markup writes the text with entities in a parse mode.
+type markup interface {
+	open(w *strings.Builder, e objects.MessageEntity)
+	close(w *strings.Builder, e objects.MessageEntity)
+	// text writes s escaped for the entities it's inside of
+	text(w *strings.Builder, s string, inside []objects.MessageEntity)
+}
+
+// render writes text with entities sorted by sortEntities using m.
+// Entities that are not properly nested are closed and reopened around the ones they overlap.
+func render(text string, entities []objects.MessageEntity, m markup) string {
+	w := &strings.Builder{}
+	var (
+		stack []objects.MessageEntity
+		next  int // the next entity to open
+		pos   int // in UTF-16 code units
+		start int // of the text not written yet, in bytes
+	)
+	end := func(e objects.MessageEntity) int {
+		return e.Offset + e.Length
+	}
+	flush := func(i int) {
+		if i > start {
+			m.text(w, text[start:i], stack)
+			start = i
+		}
+	}
+	// boundary closes the entities ending at pos and opens the ones starting there
+	boundary := func(i int) {
+		j := slices.IndexFunc(stack, func(e objects.MessageEntity) bool {
+			return end(e) <= pos
+		})
+		if j >= 0 {
+			flush(i)
+			for k := len(stack) - 1; k >= j; k-- {
+				m.close(w, stack[k])
+			}
+			// the entities opened inside the closed one continue after it
+			var reopen []objects.MessageEntity
+			for _, e := range stack[j+1:] {
+				if end(e) > pos {
+					reopen = append(reopen, e)
+				}
+			}
+			stack = append(stack[:j], reopen...)
+			for _, e := range reopen {
+				m.open(w, e)
+			}
+		}
+		for next < len(entities) && entities[next].Offset <= pos {
+			e := entities[next]
+			next++
+			if e.Length <= 0 || end(e) <= pos {
+				continue
+			}
+			flush(i)
+			m.open(w, e)
+			stack = append(stack, e)
+		}
+	}
+
+	for i, r := range text {
+		boundary(i)
+		pos += utf16.RuneLen(r)
+	}
+	boundary(len(text))
+	flush(len(text))
+	for k := len(stack) - 1; k >= 0; k-- {
+		m.close(w, stack[k])
+	}
+	return w.String()
+}
+
+type htmlMarkup struct{}
+
+func (htmlMarkup) open(w *strings.Builder, e objects.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		w.WriteString("<b>")
+	case "italic":
+		w.WriteString("<i>")
+	case "underline":
+		w.WriteString("<u>")
+	case "strikethrough":
+		w.WriteString("<s>")
+	case "spoiler":
+		w.WriteString("<tg-spoiler>")
+	case "code":
+		w.WriteString("<code>")
+	case "pre":
+		if e.Language != nil {
+			fmt.Fprintf(w, `<pre><code class="language-%s">`, html.EscapeString(*e.Language))
+		} else {
+			w.WriteString("<pre>")
+		}
+	case "text_link":
+		if e.Url != nil {
+			fmt.Fprintf(w, `<a href="%s">`, html.EscapeString(*e.Url))
+		}
+	case "text_mention":
+		if e.User != nil {
+			fmt.Fprintf(w, `<a href="tg://user?id=%d">`, e.User.Id)
+		}
+	case "custom_emoji":
+		if e.CustomEmojiId != nil {
+			fmt.Fprintf(w, `<tg-emoji emoji-id="%s">`, html.EscapeString(*e.CustomEmojiId))
+		}
+	case "blockquote":
+		w.WriteString("<blockquote>")
+	case "expandable_blockquote":
+		w.WriteString("<blockquote expandable>")
+	}
+}
+
+func (htmlMarkup) close(w *strings.Builder, e objects.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		w.WriteString("</b>")
+	case "italic":
+		w.WriteString("</i>")
+	case "underline":
+		w.WriteString("</u>")
+	case "strikethrough":
+		w.WriteString("</s>")
+	case "spoiler":
+		w.WriteString("</tg-spoiler>")
+	case "code":
+		w.WriteString("</code>")
+	case "pre":
+		if e.Language != nil {
+			w.WriteString("</code></pre>")
+		} else {
+			w.WriteString("</pre>")
+		}
+	case "text_link":
+		if e.Url != nil {
+			w.WriteString("</a>")
+		}
+	case "text_mention":
+		if e.User != nil {
+			w.WriteString("</a>")
+		}
+	case "custom_emoji":
+		if e.CustomEmojiId != nil {
+			w.WriteString("</tg-emoji>")
+		}
+	case "blockquote", "expandable_blockquote":
+		w.WriteString("</blockquote>")
+	}
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (htmlMarkup) text(w *strings.Builder, s string, _ []objects.MessageEntity) {
+	htmlEscaper.WriteString(w, s)
+}
+
+type markdownMarkup struct {
+	// the last marker written, if nothing was written after it
+	last string
+}
+
+func (m *markdownMarkup) marker(w *strings.Builder, s string) {
+	// "___" is always parsed as underline followed by italic,
+	// so italic followed by underline or italic is separated with an empty bold entity
+	if m.last == "_" && strings.HasPrefix(s, "_") {
+		w.WriteString("**")
+	}
+	w.WriteString(s)
+	m.last = s
+}
+
+func (m *markdownMarkup) open(w *strings.Builder, e objects.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		m.marker(w, "*")
+	case "italic":
+		m.marker(w, "_")
+	case "underline":
+		m.marker(w, "__")
+	case "strikethrough":
+		m.marker(w, "~")
+	case "spoiler":
+		m.marker(w, "||")
+	case "code":
+		m.marker(w, "`")
+	case "pre":
+		lang := ""
+		if e.Language != nil {
+			lang = *e.Language
+		}
+		m.marker(w, "```"+lang+"\n")
+	case "text_link":
+		if e.Url != nil {
+			m.marker(w, "[")
+		}
+	case "text_mention":
+		if e.User != nil {
+			m.marker(w, "[")
+		}
+	case "custom_emoji":
+		if e.CustomEmojiId != nil {
+			m.marker(w, "![")
+		}
+	case "blockquote":
+		m.marker(w, ">")
+	case "expandable_blockquote":
+		m.marker(w, "**>")
+	}
+}
+
+var markdownUrlEscaper = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+
+func (m *markdownMarkup) close(w *strings.Builder, e objects.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		m.marker(w, "*")
+	case "italic":
+		m.marker(w, "_")
+	case "underline":
+		m.marker(w, "__")
+	case "strikethrough":
+		m.marker(w, "~")
+	case "spoiler":
+		m.marker(w, "||")
+	case "code":
+		m.marker(w, "`")
+	case "pre":
+		m.marker(w, "```")
+	case "text_link":
+		if e.Url != nil {
+			m.marker(w, "]("+markdownUrlEscaper.Replace(*e.Url)+")")
+		}
+	case "text_mention":
+		if e.User != nil {
+			m.marker(w, fmt.Sprintf("](tg://user?id=%d)", e.User.Id))
+		}
+	case "custom_emoji":
+		if e.CustomEmojiId != nil {
+			m.marker(w, "](tg://emoji?id="+markdownUrlEscaper.Replace(*e.CustomEmojiId)+")")
+		}
+	case "expandable_blockquote":
+		m.marker(w, "||")
+	}
+}
+
+var (
+	markdownEscaper     = newEscaper(`\_*[]()~` + "`" + `>#+-=|{}.!`)
+	markdownCodeEscaper = newEscaper(`\` + "`")
+)
+
+func newEscaper(chars string) *strings.Replacer {
+	var pairs []string
+	for _, c := range chars {
+		pairs = append(pairs, string(c), `\`+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func (m *markdownMarkup) text(w *strings.Builder, s string, inside []objects.MessageEntity) {
+	m.last = ""
+	code, quote := false, false
+	for _, e := range inside {
+		switch e.Type {
+		case "code", "pre":
+			code = true
+		case "blockquote", "expandable_blockquote":
+			quote = true
+		}
+	}
+	if code {
+		s = markdownCodeEscaper.Replace(s)
+	} else {
+		s = markdownEscaper.Replace(s)
+	}
+	if quote {
+		// every line of a block quotation starts with ">"
+		s = strings.ReplaceAll(s, "\n", "\n>")
+	}
+	w.WriteString(s)
+}
@@ -0,0 +1,138 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+const (
+	// MaxTextLen is the maximum length of a message text in UTF-16 code units.
+	MaxTextLen = objects.MaxTextLen
+	// MaxCaptionLen is the maximum length of a media caption in UTF-16 code units.
+	MaxCaptionLen = objects.MaxCaptionLen
+)
+
+// Chunk is a part of a text split by [Split], with the entities moved to its offsets.
+type Chunk struct {
+	Text     string
+	Entities []objects.MessageEntity
+}
+
+// Split splits text with entities into chunks no longer than limit UTF-16 code units,
+// for example [MaxTextLen].
+//
+// Text is split at the last paragraph break fitting into the limit,
+// or the last line break if there is none, or the last space,
+// and the separator is removed. Splitting inside an entity is avoided,
+// and if it's unavoidable, the entity is split into the adjacent chunks.
+// Surrogate pairs are never split.
+// If limit is less than 1, the text isn't split.
+func Split(text string, entities []objects.MessageEntity, limit int) []Chunk {
+	return newSplitter(text, entities).split(0, limit)
+}
+
+// SplitCaption splits text with entities into a caption no longer than [MaxCaptionLen]
+// and the rest of the text split into chunks no longer than [MaxTextLen],
+// to be sent as messages following the media.
+func SplitCaption(text string, entities []objects.MessageEntity) (Chunk, []Chunk) {
+	s := newSplitter(text, entities)
+	last := len(s.units) - 1
+	if s.units[last] <= MaxCaptionLen {
+		return s.chunk(0, last), nil
+	}
+	cut, next := s.cut(0, MaxCaptionLen)
+	return s.chunk(0, cut), s.split(next, MaxTextLen)
+}
+
+type splitter struct {
+	text     string
+	entities []objects.MessageEntity
+	// the byte and UTF-16 offsets of every rune boundary, including the end of the text
+	bytes, units []int
+}
+
+func newSplitter(text string, entities []objects.MessageEntity) *splitter {
+	s := &splitter{text: text, entities: entities}
+	u := 0
+	for i, r := range text {
+		s.bytes = append(s.bytes, i)
+		s.units = append(s.units, u)
+		u += utf16.RuneLen(r)
+	}
+	s.bytes = append(s.bytes, len(text))
+	s.units = append(s.units, u)
+	return s
+}
+
+// split splits the text from the rune boundary start into chunks no longer than limit.
+func (s *splitter) split(start, limit int) []Chunk {
+	last := len(s.units) - 1
+	if limit < 1 {
+		return []Chunk{s.chunk(start, last)}
+	}
+	var chunks []Chunk
+	for s.units[last]-s.units[start] > limit {
+		cut, next := s.cut(start, limit)
+		chunks = append(chunks, s.chunk(start, cut))
+		start = next
+	}
+	return append(chunks, s.chunk(start, last))
+}
+
+// inside reports whether the UTF-16 offset p is inside an entity.
+func (s *splitter) inside(p int) bool {
+	for _, e := range s.entities {
+		if e.Offset < p && p < e.Offset+e.Length {
+			return true
+		}
+	}
+	return false
+}
+
+var separators = []string{"\n\n", "\n", " ", ""}
+
+// cut returns the last rune boundary after start to cut the text at,
+// so the chunk is no longer than limit, and the boundary the next chunk starts at, after the separator.
+func (s *splitter) cut(start, limit int) (int, int) {
+	end := start
+	for end+1 < len(s.units) && s.units[end+1]-s.units[start] <= limit {
+		end++
+	}
+	// a single rune longer than the limit
+	if end == start {
+		return start + 1, start + 1
+	}
+	for _, safe := range []bool{true, false} {
+		for _, sep := range separators {
+			for i := end; i > start; i-- {
+				if !strings.HasPrefix(s.text[s.bytes[i]:], sep) {
+					continue
+				}
+				if safe && s.inside(s.units[i]) {
+					continue
+				}
+				return i, i + len([]rune(sep))
+			}
+		}
+	}
+	// unreachable, since the text can always be cut at end
+	return end, end
+}
+
+// chunk returns the part of the text between the rune boundaries start and end,
+// with the entities clipped to it.
+func (s *splitter) chunk(start, end int) Chunk {
+	c := Chunk{Text: s.text[s.bytes[start]:s.bytes[end]]}
+	from, to := s.units[start], s.units[end]
+	for _, e := range s.entities {
+		off, last := max(e.Offset, from), min(e.Offset+e.Length, to)
+		if last <= off {
+			continue
+		}
+		e.Offset, e.Length = off-from, last-off
+		c.Entities = append(c.Entities, e)
+	}
+	return c
+}
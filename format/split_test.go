@@ -0,0 +1,44 @@
+package format_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/objects"
+)
+
+func TestSplit(t *testing.T) {
+	text := "first paragraph\n\nsecond line\nthird 😀😀 words"
+	entities := []objects.MessageEntity{
+		{Type: "bold", Offset: 0, Length: 5},
+		// "second line\nthird", so the text can't be split at the line break
+		{Type: "italic", Offset: 17, Length: 17},
+	}
+
+	chunks := format.Split(text, entities, 20)
+	var got []string
+	for _, c := range chunks {
+		got = append(got, c.Text)
+		if format.Len(c.Text) > 20 {
+			t.Fatalf("chunk %q is longer than the limit", c.Text)
+		}
+	}
+	expected := []string{"first paragraph", "second line\nthird", "😀😀 words"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Fatalf("expected chunks %q, got %q", expected, got)
+	}
+	if len(chunks[0].Entities) != 1 || chunks[0].Entities[0].Type != "bold" {
+		t.Fatalf("unexpected entities of the first chunk: %+v", chunks[0].Entities)
+	}
+	if e := chunks[1].Entities; len(e) != 1 || e[0].Offset != 0 || e[0].Length != 17 {
+		t.Fatalf("unexpected entities of the second chunk: %+v", e)
+	}
+
+	// the emoji are never split, even without separators
+	for _, c := range format.Split(strings.Repeat("😀", 5), nil, 3) {
+		if c.Text != "😀" {
+			t.Fatalf("unexpected chunk %q", c.Text)
+		}
+	}
+}
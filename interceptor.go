@@ -0,0 +1,36 @@
+package gotely
+
+import "context"
+
+// Invoker sends a request with parameters described in body to the given endpoint
+// of the Telegram Bot API and returns the decoded response.
+// An unsuccessful request is reported with [ApiResponse.Ok] set to false, not with an error;
+// errors are used for failures that prevented getting a response at all.
+type Invoker func(ctx context.Context, endpoint string, body Method) (*ApiResponse, error)
+
+// Interceptor wraps an [Invoker], similarly to [http.RoundTripper] middleware.
+// It can inspect or modify the request and the response,
+// or return a response without calling next at all.
+// It's useful for logging, metrics, caching, auditing and so on.
+//
+// Example:
+//
+//	func Logging(next gotely.Invoker) gotely.Invoker {
+//		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+//			start := time.Now()
+//			resp, err := next(ctx, endpoint, body)
+//			slog.Info(endpoint, "took", time.Since(start), "err", err)
+//			return resp, err
+//		}
+//	}
+type Interceptor func(next Invoker) Invoker
+
+// WithInterceptors adds interceptors wrapping every attempt to send a request.
+// The first interceptor is the outermost one.
+// Retries and follow-ups of group migrations are made outside of interceptors,
+// so every attempt goes through the whole chain.
+func WithInterceptors(i ...Interceptor) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.Interceptors = append(rc.Interceptors, i...)
+	}
+}
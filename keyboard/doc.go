@@ -0,0 +1,5 @@
+// This package provides builders for inline and reply keyboards,
+// with helpers for laying out the buttons in rows and constructors for every kind of button.
+//
+// Licensed under the MIT License. See LICENSE file for details.
+package keyboard
@@ -0,0 +1,125 @@
+package keyboard
+
+import "github.com/bigelle/gotely/objects"
+
+// Inline builds an [objects.InlineKeyboardMarkup].
+//
+// Example:
+//
+//	markup, err := keyboard.NewInline().
+//		Add(
+//			keyboard.Callback("1", "page:1"),
+//			keyboard.Callback("2", "page:2"),
+//			keyboard.Callback("3", "page:3"),
+//		).
+//		Row(keyboard.Url("Docs", "https://core.telegram.org/bots/api")).
+//		Markup()
+//
+// Buttons are laid out with [Inline.Add], [Inline.Row], [Inline.Width] and [Inline.Adjust],
+// and validated by [Inline.Markup], before the keyboard is sent.
+// The zero value is an empty keyboard ready to use.
+type Inline struct {
+	layout layout[objects.InlineKeyboardButton]
+}
+
+// NewInline creates a new empty [Inline] keyboard.
+func NewInline() *Inline {
+	return &Inline{}
+}
+
+// Add appends buttons to the last row.
+// If the width is set with [Inline.Width], a new row is started every time the last one is full.
+func (k *Inline) Add(buttons ...objects.InlineKeyboardButton) *Inline {
+	k.layout.add(buttons)
+	return k
+}
+
+// Row starts a new row with buttons. The buttons added next with [Inline.Add] are appended to this row.
+func (k *Inline) Row(buttons ...objects.InlineKeyboardButton) *Inline {
+	k.layout.row(buttons)
+	return k
+}
+
+// Width sets the maximum number of buttons in the rows filled by [Inline.Add].
+// Zero, the default, means no limit.
+func (k *Inline) Width(width int) *Inline {
+	k.layout.width = max(width, 0)
+	return k
+}
+
+// Adjust rearranges every button added so far in rows of the given sizes, the last size being repeated.
+// For example, Adjust(1, 2) puts the first button in its own row and the rest two per row.
+func (k *Inline) Adjust(sizes ...int) *Inline {
+	k.layout.adjust(sizes)
+	return k
+}
+
+// Markup returns the keyboard, or [gotely.ErrFailedValidation] if it's not valid.
+// Empty rows are omitted.
+func (k *Inline) Markup() (objects.InlineKeyboardMarkup, error) {
+	markup := objects.InlineKeyboardMarkup{Keyboard: k.layout.keyboard()}
+	return markup, k.layout.validate(markup)
+}
+
+// ReplyMarkup is the same as [Inline.Markup], returning the keyboard as [objects.ReplyMarkup]
+// for the methods accepting any kind of reply markup, such as [methods.SendMessage].
+func (k *Inline) ReplyMarkup() (objects.ReplyMarkup, error) {
+	markup, err := k.Markup()
+	return objects.ReplyMarkup{ReplyMarkupInterface: markup}, err
+}
+
+// Callback creates a button sending a callback query with data to the bot when pressed.
+// The data must be 1-64 bytes long.
+func Callback(text, data string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, CallbackData: &data}
+}
+
+// Url creates a button opening an HTTP or tg:// URL.
+func Url(text, url string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, Url: &url}
+}
+
+// WebApp creates a button launching the Web App at url.
+func WebApp(text, url string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, WebApp: &objects.WebAppInfo{Url: url}}
+}
+
+// LoginUrl creates a button authorizing the user with login, as a replacement for the Telegram Login Widget.
+func LoginUrl(text string, login objects.LoginUrl) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, LoginUrl: &login}
+}
+
+// SwitchInlineQuery creates a button prompting the user to select a chat and inserting
+// the bot's username and query in its input field. The query may be empty.
+func SwitchInlineQuery(text, query string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, SwitchInlineQuery: &query}
+}
+
+// SwitchInlineQueryCurrentChat creates a button inserting the bot's username and query
+// in the input field of the current chat. The query may be empty.
+func SwitchInlineQueryCurrentChat(text, query string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: &query}
+}
+
+// SwitchInlineQueryChosenChat creates a button prompting the user to select a chat of the types allowed by chat
+// and inserting the bot's username and the query in its input field.
+func SwitchInlineQueryChosenChat(text string, chat objects.SwitchInlineQueryChosenChat) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, SwitchInlineQueryChosenChat: &chat}
+}
+
+// CopyText creates a button copying copied to the clipboard. The copied text must be 1-256 characters long.
+func CopyText(text, copied string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, CopyText: &objects.CopyTextButton{Text: copied}}
+}
+
+// CallbackGame creates a button launching the game of the message.
+// It must be the first button in the first row.
+func CallbackGame(text string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, CallbackGame: &objects.CallbackGame{}}
+}
+
+// Pay creates a Pay button for an invoice message.
+// It must be the first button in the first row.
+func Pay(text string) objects.InlineKeyboardButton {
+	return objects.InlineKeyboardButton{Text: text, Pay: ptr(true)}
+}
@@ -0,0 +1,81 @@
+package keyboard_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/keyboard"
+)
+
+func TestInline(t *testing.T) {
+	kb := keyboard.NewInline().Width(2)
+	for _, n := range []string{"1", "2", "3", "4", "5"} {
+		kb.Add(keyboard.Callback(n, "page:"+n))
+	}
+	kb.Row(keyboard.Url("Docs", "https://core.telegram.org/bots/api"))
+	markup, err := kb.Markup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sizes := rowSizes(markup.Keyboard); sizes != "2 2 1 1" {
+		t.Fatalf("expected rows of 2 2 1 1 buttons, got %s", sizes)
+	}
+
+	markup, _ = kb.Adjust(1, 3).Markup()
+	if sizes := rowSizes(markup.Keyboard); sizes != "1 3 2" {
+		t.Fatalf("expected rows of 1 3 2 buttons, got %s", sizes)
+	}
+
+	rm, err := keyboard.NewInline().Add(keyboard.Pay("Pay 10 XTR")).ReplyMarkup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(rm)
+	if expected := `{"inline_keyboard":[[{"text":"Pay 10 XTR","pay":true}]]}`; string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestInlineValidation(t *testing.T) {
+	_, err := keyboard.NewInline().
+		Add(keyboard.Callback("long", strings.Repeat("x", 65))).
+		Add(keyboard.Pay("Pay")).
+		Markup()
+	if !errors.Is(err, gotely.ErrFailedValidation{}) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "callback_data") || !strings.Contains(err.Error(), "pay") {
+		t.Fatalf("expected errors about callback_data and the pay button, got %v", err)
+	}
+}
+
+func TestReply(t *testing.T) {
+	markup, err := keyboard.NewReply().
+		Add(keyboard.Button("Yes"), keyboard.Button("No")).
+		Row(keyboard.RequestPoll("Quiz", "quiz")).
+		Resize().
+		Markup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sizes := rowSizes(markup.Keyboard); sizes != "2 1" {
+		t.Fatalf("expected rows of 2 1 buttons, got %s", sizes)
+	}
+
+	_, err = keyboard.NewReply().Add(keyboard.RequestPoll("Poll", "multiple choice")).Markup()
+	if err == nil {
+		t.Fatal("expected an error for an unknown poll type")
+	}
+}
+
+func rowSizes[B any](rows [][]B) string {
+	var sizes []string
+	for _, row := range rows {
+		sizes = append(sizes, strconv.Itoa(len(row)))
+	}
+	return strings.Join(sizes, " ")
+}
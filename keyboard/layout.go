@@ -0,0 +1,80 @@
+package keyboard
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bigelle/gotely"
+)
+
+// layout arranges buttons of type B in rows.
+type layout[B any] struct {
+	rows  [][]B
+	width int
+	err   error
+}
+
+// add appends buttons to the last row, starting a new one when the row is full.
+func (l *layout[B]) add(buttons []B) {
+	for _, b := range buttons {
+		n := len(l.rows)
+		if n == 0 || (l.width > 0 && len(l.rows[n-1]) >= l.width) {
+			l.rows = append(l.rows, nil)
+			n++
+		}
+		l.rows[n-1] = append(l.rows[n-1], b)
+	}
+}
+
+// row starts a new row with buttons.
+func (l *layout[B]) row(buttons []B) {
+	l.rows = append(l.rows, slices.Clone(buttons))
+}
+
+// adjust rearranges every button in rows of the given sizes, repeating the last one.
+func (l *layout[B]) adjust(sizes []int) {
+	if len(sizes) == 0 {
+		return
+	}
+	for _, size := range sizes {
+		if size < 1 {
+			l.err = fmt.Errorf("row sizes must be positive, got %d", size)
+			return
+		}
+	}
+
+	buttons := slices.Concat(l.rows...)
+	l.rows = nil
+	for i := 0; len(buttons) > 0; i++ {
+		size := sizes[min(i, len(sizes)-1)]
+		size = min(size, len(buttons))
+		l.rows = append(l.rows, buttons[:size:size])
+		buttons = buttons[size:]
+	}
+}
+
+// keyboard returns the rows that have at least one button.
+func (l *layout[B]) keyboard() [][]B {
+	rows := make([][]B, 0, len(l.rows))
+	for _, row := range l.rows {
+		if len(row) > 0 {
+			rows = append(rows, slices.Clone(row))
+		}
+	}
+	return rows
+}
+
+// validate reports the layout error, if any, along with the errors of the keyboard.
+func (l *layout[B]) validate(kb interface{ Validate() error }) error {
+	var err gotely.ErrFailedValidation
+	if l.err != nil {
+		err = append(err, l.err)
+	}
+	if er := kb.Validate(); er != nil {
+		err = append(err, er)
+	}
+	if len(err) > 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package keyboard
+
+import "github.com/bigelle/gotely/objects"
+
+// Reply builds an [objects.ReplyKeyboardMarkup].
+//
+// Example:
+//
+//	markup, err := keyboard.NewReply().
+//		Add(keyboard.Button("Yes"), keyboard.Button("No")).
+//		Row(keyboard.RequestContact("Share my phone number")).
+//		Resize().
+//		OneTime().
+//		ReplyMarkup()
+//
+// The buttons are laid out in the same way as in [Inline].
+// The zero value is an empty keyboard ready to use.
+type Reply struct {
+	layout layout[objects.KeyboardButton]
+	markup objects.ReplyKeyboardMarkup
+}
+
+// NewReply creates a new empty [Reply] keyboard.
+func NewReply() *Reply {
+	return &Reply{}
+}
+
+// Add appends buttons to the last row.
+// If the width is set with [Reply.Width], a new row is started every time the last one is full.
+func (k *Reply) Add(buttons ...objects.KeyboardButton) *Reply {
+	k.layout.add(buttons)
+	return k
+}
+
+// Row starts a new row with buttons. The buttons added next with [Reply.Add] are appended to this row.
+func (k *Reply) Row(buttons ...objects.KeyboardButton) *Reply {
+	k.layout.row(buttons)
+	return k
+}
+
+// Width sets the maximum number of buttons in the rows filled by [Reply.Add].
+// Zero, the default, means no limit.
+func (k *Reply) Width(width int) *Reply {
+	k.layout.width = max(width, 0)
+	return k
+}
+
+// Adjust rearranges every button added so far in rows of the given sizes, the last size being repeated.
+func (k *Reply) Adjust(sizes ...int) *Reply {
+	k.layout.adjust(sizes)
+	return k
+}
+
+// Persistent requests clients to always show the keyboard when the regular keyboard is hidden.
+func (k *Reply) Persistent() *Reply {
+	k.markup.IsPersistent = ptr(true)
+	return k
+}
+
+// Resize requests clients to resize the keyboard vertically for optimal fit.
+func (k *Reply) Resize() *Reply {
+	k.markup.ResizeKeyboard = ptr(true)
+	return k
+}
+
+// OneTime requests clients to hide the keyboard as soon as it's been used.
+func (k *Reply) OneTime() *Reply {
+	k.markup.OneTimeKeyboard = ptr(true)
+	return k
+}
+
+// Placeholder sets the placeholder shown in the input field when the keyboard is active; 1-64 characters.
+func (k *Reply) Placeholder(placeholder string) *Reply {
+	k.markup.InputFieldPlaceholder = &placeholder
+	return k
+}
+
+// Selective shows the keyboard only to the users mentioned in the text of the message
+// and to the sender of the message the bot replies to.
+func (k *Reply) Selective() *Reply {
+	k.markup.Selective = ptr(true)
+	return k
+}
+
+// Markup returns the keyboard, or [gotely.ErrFailedValidation] if it's not valid.
+// Empty rows are omitted.
+func (k *Reply) Markup() (objects.ReplyKeyboardMarkup, error) {
+	markup := k.markup
+	markup.Keyboard = k.layout.keyboard()
+	return markup, k.layout.validate(markup)
+}
+
+// ReplyMarkup is the same as [Reply.Markup], returning the keyboard as [objects.ReplyMarkup].
+func (k *Reply) ReplyMarkup() (objects.ReplyMarkup, error) {
+	markup, err := k.Markup()
+	return objects.ReplyMarkup{ReplyMarkupInterface: markup}, err
+}
+
+// Remove returns the reply markup removing the current custom keyboard.
+// If selective is true, it's removed only for the users mentioned in the text of the message
+// and for the sender of the message the bot replies to.
+func Remove(selective bool) objects.ReplyMarkup {
+	remove := objects.ReplyKeyboardRemove{RemoveKeyboard: true}
+	if selective {
+		remove.Selective = ptr(true)
+	}
+	return objects.ReplyMarkup{ReplyMarkupInterface: remove}
+}
+
+// Button creates a button sending its text as a message when pressed.
+func Button(text string) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text}
+}
+
+// RequestContact creates a button sending the user's phone number as a contact. Available in private chats only.
+func RequestContact(text string) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text, RequestContact: ptr(true)}
+}
+
+// RequestLocation creates a button sending the user's current location. Available in private chats only.
+func RequestLocation(text string) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text, RequestLocation: ptr(true)}
+}
+
+// RequestPoll creates a button asking the user to create a poll and send it to the bot.
+// The poll type is "quiz", "regular", or empty to allow polls of any type. Available in private chats only.
+func RequestPoll(text, pollType string) objects.KeyboardButton {
+	poll := objects.KeyboardButtonPollType{}
+	if pollType != "" {
+		poll.Type = &pollType
+	}
+	return objects.KeyboardButton{Text: text, RequestPoll: &poll}
+}
+
+// RequestUsers creates a button opening a list of the users matching users.
+// The identifiers of the selected users are sent to the bot in a “users_shared” service message.
+// Available in private chats only.
+func RequestUsers(text string, users objects.KeyboardButtonRequestUsers) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text, RequestUsers: &users}
+}
+
+// RequestChat creates a button opening a list of the chats matching chat.
+// The identifier of the selected chat is sent to the bot in a “chat_shared” service message.
+// Available in private chats only.
+func RequestChat(text string, chat objects.KeyboardButtonRequestChat) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text, RequestChat: &chat}
+}
+
+// ReplyWebApp creates a reply keyboard button launching the Web App at url.
+// The Web App will be able to send a “web_app_data” service message. Available in private chats only.
+func ReplyWebApp(text, url string) objects.KeyboardButton {
+	return objects.KeyboardButton{Text: text, WebApp: &objects.WebAppInfo{Url: url}}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,107 @@
+package gotely
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WithLocalServer configures the client to work with a local Bot API server
+// (https://github.com/tdlib/telegram-bot-api) started with the --local flag.
+// url is the API URL template of the server, for example "http://localhost:8081/bot<token>/<method>".
+//
+// In this mode, files can be sent with [objects.InputFileFromPath] without uploading them.
+// Files returned by [methods.GetFile] have absolute paths and are read directly from the disk,
+// see [WithLocalFilesDir] if the server's working directory is mounted elsewhere.
+func WithLocalServer(url string) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.ApiUrl = url
+		rc.LocalServer = true
+	}
+}
+
+// WithLocalFilesDir maps the working directory of a local Bot API server, passed to it with the --dir flag,
+// to the directory where it's available for the bot.
+// It is useful when the server runs in a container with its working directory mounted as a volume.
+func WithLocalFilesDir(serverDir, localDir string) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.LocalServerDir = serverDir
+		rc.LocalFilesDir = localDir
+	}
+}
+
+// IsLocal reports whether the client is configured to work with a local Bot API server.
+func (c *Client) IsLocal() bool {
+	return c.cfg.LocalServer
+}
+
+// localPath maps the path of a file on a local Bot API server to the path available for the bot.
+func (c *Client) localPath(filePath string) string {
+	if c.cfg.LocalServerDir == "" {
+		return filePath
+	}
+	rel, err := filepath.Rel(c.cfg.LocalServerDir, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filePath
+	}
+	return filepath.Join(c.cfg.LocalFilesDir, rel)
+}
+
+// MoveTo moves the bot to another Bot API server and returns the client for it.
+// The new client is configured with the same options as c, with opts applied on top of them,
+// for example [WithLocalServer] or [WithUrl] with [DEFAULT_URL_TEMPLATE].
+// The settings of a local server, including [WithLocalFilesDir], aren't kept and have to be passed again.
+//
+// If c is working with the cloud Bot API server, the bot is logged out from it with logOut.
+// After that, the bot can't log in back to the cloud server for 10 minutes.
+// If c is working with a local server, the webhook is removed and the bot instance is closed with close,
+// so it isn't launched again after the server restarts.
+// Note that close returns error 429 in the first 10 minutes after the bot is launched.
+func (c *Client) MoveTo(ctx context.Context, opts ...RequestOption) (*Client, error) {
+	if c.IsLocal() {
+		if err := c.Do(ctx, parameterless("deleteWebhook"), nil); err != nil {
+			return nil, err
+		}
+		if err := c.Do(ctx, parameterless("close"), nil); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := c.Do(ctx, parameterless("logOut"), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := c.cfg
+	// the new server isn't local unless it's set explicitly
+	cfg.LocalServer = false
+	cfg.LocalServerDir, cfg.LocalFilesDir = "", ""
+	cfg.Interceptors = slices.Clone(cfg.Interceptors)
+	next := &Client{
+		token: c.token,
+		cfg:   applyReqOpts(cfg, opts...),
+	}
+	next.chain()
+	return next, nil
+}
+
+// parameterless is a method that requires no parameters,
+// used for the requests that are sent by the package itself.
+type parameterless string
+
+func (p parameterless) Endpoint() string {
+	return string(p)
+}
+
+func (p parameterless) Validate() error {
+	return nil
+}
+
+func (p parameterless) Reader() io.Reader {
+	return strings.NewReader("{}")
+}
+
+func (p parameterless) ContentType() string {
+	return "application/json"
+}
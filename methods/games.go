@@ -11,6 +11,8 @@ import (
 // Use this method to send a game.
 // On success, the sent [objects.Message] is returned.
 type SendGame struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat
 	ChatId int `json:"chat_id"`
@@ -78,6 +80,8 @@ func (s SendGame) ContentType() string {
 // On success, if the message is not an inline message, the [objects.Message] is returned, otherwise True is returned.
 // Returns an error, if the new score is not greater than the user's current score in the chat and force is False.
 type SetGameScore struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// REQUIRED:
 	// User identifier
 	UserId int `json:"user_id"`
@@ -143,6 +147,8 @@ func (s SetGameScore) ContentType() string {
 // Will also return the top three users if the user and their neighbors are not among them.
 // Please note that this behavior is subject to change.
 type GetGameHighScores struct {
+	gotely.Returns[[]objects.GameHighScore]
+
 	// REQUIRED:
 	// Target user id
 	UserId int `json:"user_id"`
@@ -13,6 +13,8 @@ import (
 //
 // No more than 50 results per query are allowed.
 type AnswerInlineQuery struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the answered query
 	InlineQueryId string `json:"inline_query_id"`
@@ -76,6 +78,8 @@ func (s AnswerInlineQuery) ContentType() string {
 // a corresponding message on behalf of the user to the chat from which the query originated.
 // On success, a [objects.SentWebAppMessage] object is returned.
 type AnswerWebAppQuery struct {
+	gotely.Returns[objects.SentWebAppMessage]
+
 	// REQUIRED:
 	// Unique identifier for the query to be answered
 	WebAppQueryId string `json:"web_app_query_id"`
@@ -113,6 +117,8 @@ func (s AnswerWebAppQuery) ContentType() string {
 // Stores a message that can be sent by a user of a Mini App.
 // Returns a [objects.PreparedInlineMessage] object.
 type SavePreparedInlineMessage struct {
+	gotely.Returns[objects.PreparedInlineMessage]
+
 	// REQUIRED:
 	// Unique identifier of the target user that can use the prepared message
 	UserId int `json:"user_id"`
@@ -12,7 +12,9 @@ import (
 // A simple method for testing your bot's authentication token.
 // Requires no parameters.
 // Returns basic information about the bot in form of a [objects.User] object.
-type GetMe struct{}
+type GetMe struct {
+	gotely.Returns[objects.User]
+}
 
 func (g GetMe) Validate() error {
 	return nil
@@ -35,7 +37,9 @@ func (g GetMe) ContentType() string {
 // After a successful call, you can immediately log in on a local server,
 // but will not be able to log in back to the cloud Bot API server for 10 minutes.
 // Returns True on success. Requires no parameters.
-type LogOut struct{}
+type LogOut struct {
+	gotely.Returns[bool]
+}
 
 func (g LogOut) Validate() error {
 	return nil
@@ -57,7 +61,9 @@ func (g LogOut) ContentType() string {
 // You need to delete the webhook before calling this method to ensure that the bot isn't launched again after server restart.
 // The method will return error 429 in the first 10 minutes after the bot is launched.
 // Returns True on success. Requires no parameters.
-type Close struct{}
+type Close struct {
+	gotely.Returns[bool]
+}
 
 func (g Close) Validate() error {
 	return nil
@@ -78,6 +84,8 @@ func (g Close) ContentType() string {
 // Use this method to send text messages.
 // On success, the sent [objects.Message] is returned.
 type SendMessage struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -114,8 +122,8 @@ type SendMessage struct {
 
 func (s SendMessage) Validate() error {
 	var err gotely.ErrFailedValidation
-	l := len(s.Text)
-	if l < 1 || l > 4096 {
+	l := objects.UTF16Len(s.Text)
+	if l < 1 || l > objects.MaxTextLen {
 		err = append(err, fmt.Errorf("text parameter must be between 1 and 4096 characters"))
 	}
 	if s.ChatId == "" {
@@ -161,6 +169,8 @@ func (s SendMessage) ContentType() string {
 // Service messages and messages with protected content can't be forwarded.
 // On success, the sent [objects.Message] is returned.
 type ForwardMessage struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -216,6 +226,8 @@ func (s ForwardMessage) ContentType() string {
 // Album grouping is kept for forwarded messages.
 // On success, an array of [objects.MessageId] of the sent messages is returned.
 type ForwardMessages struct {
+	gotely.Returns[[]objects.MessageId]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -280,6 +292,8 @@ func (s ForwardMessages) ContentType() string {
 // The method is analogous to the method forwardMessage, but the copied message doesn't have a link to the original message.
 // Returns the [objects.MessageId] of the sent message on success.
 type CopyMessage struct {
+	gotely.Returns[objects.MessageId]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -329,6 +343,9 @@ func (c CopyMessage) Validate() error {
 	if c.MessageId < 1 {
 		err = append(err, fmt.Errorf("message_ids parameter can't be empty"))
 	}
+	if c.Caption != nil && objects.UTF16Len(*c.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if c.CaptionEntities != nil {
 		for _, ent := range *c.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -368,6 +385,8 @@ func (s CopyMessage) ContentType() string {
 // Album grouping is kept for copied messages.
 // On success, an array of [objects.MessageId] of the sent messages is returned.
 type CopyMessages struct {
+	gotely.Returns[[]objects.MessageId]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -431,6 +450,8 @@ func (s CopyMessages) ContentType() string {
 // Use this method to send photos.
 // On success, the sent [objects.Message] is returned.
 type SendPhoto struct {
+	gotely.Returns[objects.Message]
+
 	//REQUIRED:
 	//Unique identifier for the target chat or username of the target channel
 	//(in the format @channelusername)
@@ -484,6 +505,9 @@ func (s SendPhoto) Validate() error {
 	if er := s.Photo.Validate(); er != nil {
 		err = append(err, er)
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -620,6 +644,8 @@ func (s SendPhoto) ContentType() string {
 //
 // For sending voice messages, use the [SendVoice] method instead.
 type SendAudio struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -679,6 +705,9 @@ func (s SendAudio) Validate() error {
 	if er := s.Audio.Validate(); er != nil {
 		err = append(err, er)
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -830,6 +859,8 @@ func (s SendAudio) ContentType() string {
 // On success, the sent [objects.Message] is returned.
 // Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 type SendDocument struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -888,6 +919,9 @@ func (s SendDocument) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -1022,6 +1056,8 @@ func (s SendDocument) ContentType() string {
 // On success, the sent [objects.Message] is returned.
 // Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 type SendVideo struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -1243,6 +1279,9 @@ func (s SendVideo) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -1273,6 +1312,8 @@ func (s SendVideo) ContentType() string {
 // On success, the sent [objects.Message] is returned.
 // Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 type SendAnimation struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -1343,6 +1384,9 @@ func (s SendAnimation) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -1493,6 +1537,8 @@ func (s SendAnimation) ContentType() string {
 // On success, the sent [objects.Message] is returned. Bots can currently send voice messages of up to 50 MB in size,
 // this limit may be changed in the future.
 type SendVoice struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -1540,6 +1586,9 @@ func (s SendVoice) Validate() error {
 	if er := s.Voice.Validate(); er != nil {
 		err = append(err, er)
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -1668,6 +1717,8 @@ func (s SendVoice) ContentType() string {
 // Use this method to send video messages.
 // On success, the sent [objects.Message] is returned.
 type SendVideoNote struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -1836,6 +1887,8 @@ func (s SendVideoNote) ContentType() string {
 // Use this method to send paid media.
 // On success, the sent Message is returned.
 type SendPaidMedia struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername).
 	// If the chat is a channel, all Telegram Star proceeds from this media will be credited to the chat's balance.
@@ -1896,6 +1949,9 @@ func (s SendPaidMedia) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if s.Caption != nil && objects.UTF16Len(*s.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if s.CaptionEntities != nil {
 		for _, ent := range *s.CaptionEntities {
 			if er := ent.Validate(); er != nil {
@@ -2025,6 +2081,8 @@ func (s SendPaidMedia) ContentType() string {
 // Documents and audio files can be only grouped in an album with messages of the same type.
 // On success, an array of [objects.Messages] that were sent is returned.
 type SendMediaGroup struct {
+	gotely.Returns[[]objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2161,6 +2219,8 @@ func (s SendMediaGroup) ContentType() string {
 // Use this method to send point on the map.
 // On success, the sent [objects.Message] is returned.
 type SendLocation struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2259,6 +2319,8 @@ func (s SendLocation) ContentType() string {
 // Use this method to send information about a venue.
 // On success, the sent [objects.Message] is returned.
 type SendVenue struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2346,6 +2408,8 @@ func (s SendVenue) ContentType() string {
 // Use this method to send phone contacts.
 // On success, the sent [objects.Message] is returned.
 type SendContact struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2417,6 +2481,8 @@ func (s SendContact) ContentType() string {
 // Use this method to send a native poll.
 // On success, the sent [objects.Message] is returned.
 type SendPoll struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2525,6 +2591,8 @@ func (s SendPoll) ContentType() string {
 // Use this method to send an animated emoji that will display a random value.
 // On success, the sent [objects.Message] is returned.
 type SendDice struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2606,6 +2674,8 @@ func (s SendDice) ContentType() string {
 //
 // We only recommend using this method when a response from the bot will take a noticeable amount of time to arrive.
 type SendChatAction struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2670,6 +2740,8 @@ func (s SendChatAction) ContentType() string {
 // Bots can't use paid reactions.
 // Returns True on success.
 type SetMessageReaction struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2721,6 +2793,8 @@ func (s SetMessageReaction) ContentType() string {
 // Use this method to get a list of profile pictures for a user.
 // Returns a [objects.UserProfilePhotos] object.
 type GetUserProfilePhotos struct {
+	gotely.Returns[objects.UserProfilePhotos]
+
 	// REQUIRED:
 	// Unique identifier of the target user
 	UserId int `json:"user_id"`
@@ -2763,6 +2837,8 @@ func (s GetUserProfilePhotos) ContentType() string {
 // Mini App method requestEmojiStatusAccess.
 // Returns True on success.
 type SetUserEmojiStatus struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the target user
 	UserId int `json:"user_id"`
@@ -2806,6 +2882,8 @@ func (s SetUserEmojiStatus) ContentType() string {
 // Note: This function may not preserve the original file name and MIME type.
 // You should save the file's MIME type and name (if available) when the File object is received.
 type GetFile struct {
+	gotely.Returns[objects.File]
+
 	// REQUIRED:
 	// File identifier to get information about
 	FileId string `json:"file_id"`
@@ -2839,6 +2917,8 @@ func (s GetFile) ContentType() string {
 // etc., unless unbanned first. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type BanChatMember struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target group or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2889,6 +2969,8 @@ func (s BanChatMember) ContentType() string {
 // So if the user is a member of the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned.
 // Returns True on success.
 type UnbanChatMember struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target group or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -2931,6 +3013,8 @@ func (s UnbanChatMember) ContentType() string {
 // Pass True for all permissions to lift restrictions from a user.
 // Returns True on success.
 type RestrictChatMember struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -2982,6 +3066,8 @@ func (s RestrictChatMember) ContentType() string {
 // Pass False for all boolean parameters to demote a user.
 // Returns True on success
 type PromoteChatMember struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3054,6 +3140,8 @@ func (s PromoteChatMember) ContentType() string {
 // Use this method to set a custom title for an administrator in a supergroup promoted by the bot.
 // Returns True on success.
 type SetChatAdministratorCustomTitle struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -3108,6 +3196,8 @@ func (s SetChatAdministratorCustomTitle) ContentType() string {
 // The bot must be an administrator in the supergroup or channel for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type BanChatSenderChat struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3146,6 +3236,8 @@ func (s BanChatSenderChat) ContentType() string {
 // The bot must be an administrator for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type UnbanChatSenderChat struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3184,6 +3276,8 @@ func (s UnbanChatSenderChat) ContentType() string {
 // The bot must be an administrator in the group or a supergroup for this to work and must have the can_restrict_members administrator rights.
 // Returns True on success.
 type SetChatPermissions struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -3230,6 +3324,8 @@ func (s SetChatPermissions) ContentType() string {
 // If you want your bot to work with invite links, it will need to generate its own link using exportChatInviteLink or by calling the getChat method.
 // If your bot needs to generate a new primary invite link replacing its previous one, use exportChatInviteLink again.
 type ExportChatInviteLink struct {
+	gotely.Returns[string]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3263,6 +3359,8 @@ func (s ExportChatInviteLink) ContentType() string {
 // The link can be revoked using the method [RevokeChatInviteLink].
 // Returns the new invite link as [objects.ChatInviteLink] object.
 type CreateInviteLink struct {
+	gotely.Returns[objects.ChatInviteLink]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3314,6 +3412,8 @@ func (s CreateInviteLink) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns the edited invite link as a [objects.ChatInviteLink] object.
 type EditChatInviteLink struct {
+	gotely.Returns[objects.ChatInviteLink]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3369,6 +3469,8 @@ func (s EditChatInviteLink) ContentType() string {
 // The link can be edited using the method [EditChatSubscriptionInviteLink] or revoked using the method [RevokeChatInviteLink].
 // Returns the new invite link as a [objects.ChatInviteLink] object.
 type CreateChatSubscriptionInviteLink struct {
+	gotely.Returns[objects.ChatInviteLink]
+
 	// REQUIRED:
 	// Unique identifier for the target channel chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3421,6 +3523,8 @@ func (s CreateChatSubscriptionInviteLink) ContentType() string {
 // The bot must have the can_invite_users administrator rights.
 // Returns the edited invite link as a [objects.ChatInviteLink] object.
 type EditChatSubscriptionInviteLink struct {
+	gotely.Returns[objects.ChatInviteLink]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3468,6 +3572,8 @@ func (s EditChatSubscriptionInviteLink) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns the revoked invite link as [objects.ChatInviteLink] object.
 type RevokeInviteLink struct {
+	gotely.Returns[objects.ChatInviteLink]
+
 	// Unique identifier of the target chat or username of the target channel (in the format @channelusername)
 	// REQUIRED:
 	ChatId string `json:"chat_id"`
@@ -3506,6 +3612,8 @@ func (s RevokeInviteLink) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right.
 // Returns True on success.
 type ApproveChatJoinRequest struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3544,6 +3652,8 @@ func (s ApproveChatJoinRequest) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right.
 // Returns True on success.
 type DeclineChatJoinRequest struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3583,6 +3693,8 @@ func (s DeclineChatJoinRequest) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatPhoto struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3644,6 +3756,8 @@ func (s SetChatPhoto) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type DeleteChatPhoto struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3676,6 +3790,8 @@ func (s DeleteChatPhoto) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatTitle struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3714,6 +3830,8 @@ func (s SetChatTitle) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatDescription struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3753,6 +3871,8 @@ func (s SetChatDescription) ContentType() string {
 // must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel.
 // Returns True on success.
 type PinChatMessage struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3798,6 +3918,8 @@ func (s PinChatMessage) ContentType() string {
 // must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel.
 // Returns True on success.
 type UnpinChatMessage struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3842,6 +3964,8 @@ func (s UnpinChatMessage) ContentType() string {
 // must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel.
 // Returns True on success.
 type UnpinAllChatMessages struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3873,6 +3997,8 @@ func (p UnpinAllChatMessages) Validate() error {
 // Use this method for your bot to leave a group, supergroup or channel.
 // Returns True on success.
 type LeaveChat struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3904,6 +4030,8 @@ func (s LeaveChat) ContentType() string {
 // Use this method to get up-to-date information about the chat.
 // Returns a [objects.ChatFullInfo] object on success.
 type GetChat struct {
+	gotely.Returns[objects.ChatFullInfo]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3935,6 +4063,8 @@ func (s GetChat) ContentType() string {
 // Use this method to get a list of administrators in a chat, which aren't bots.
 // Returns an Array of [objects.ChatMember] objects.
 type GetChatAdministrators struct {
+	gotely.Returns[[]objects.ChatMember]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3966,6 +4096,8 @@ func (s GetChatAdministrators) ContentType() string {
 // Use this method to get the number of members in a chat.
 // Returns Int on success.
 type GetChatMemberCount struct {
+	gotely.Returns[int]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -3998,6 +4130,8 @@ func (s GetChatMemberCount) ContentType() string {
 // The method is only guaranteed to work for other users if the bot is an administrator in the chat.
 // Returns a [objects.ChatMember] object on success.
 type GetChatMember struct {
+	gotely.Returns[objects.ChatMember]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup or channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -4037,6 +4171,8 @@ func (s GetChatMember) ContentType() string {
 // Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method.
 // Returns True on success.
 type SetChatStickerSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4076,6 +4212,8 @@ func (s SetChatStickerSet) ContentType() string {
 // Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method.
 // Returns True on success.
 type DeleteChatStickerSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4106,7 +4244,9 @@ func (s DeleteChatStickerSet) ContentType() string {
 
 // Use this method to get custom emoji stickers, which can be used as a forum topic icon by any user.
 // Requires no parameters. Returns an Array of Sticker objects.
-type GetForumTopicIconStickers struct{}
+type GetForumTopicIconStickers struct {
+	gotely.Returns[[]objects.Sticker]
+}
 
 func (g GetForumTopicIconStickers) Validate() error {
 	return nil
@@ -4128,6 +4268,8 @@ func (s GetForumTopicIconStickers) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights.
 // Returns information about the created topic as a [ForumTopic] object.
 type CreateForumTopic struct {
+	gotely.Returns[objects.ForumTopic]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4186,6 +4328,8 @@ func (s CreateForumTopic) ContentType() string {
 // must have the can_manage_topics administrator rights, unless it is the creator of the topic.
 // Returns True on success.
 type EditForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4236,6 +4380,8 @@ func (s EditForumTopic) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic.
 // Returns True on success.
 type CloseForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4275,6 +4421,8 @@ func (s CloseForumTopic) ContentType() string {
 // must have the can_manage_topics administrator rights, unless it is the creator of the topic.
 // Returns True on success.
 type ReopenForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4313,6 +4461,8 @@ func (s ReopenForumTopic) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_delete_messages administrator rights.
 // Returns True on success.
 type DeleteForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4352,6 +4502,8 @@ func (s DeleteForumTopic) ContentType() string {
 // must have the can_pin_messages administrator right in the supergroup.
 // Returns True on success.
 type UnpinAllForumTopicMessages struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4390,6 +4542,8 @@ func (s UnpinAllForumTopicMessages) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights.
 // Returns True on success.
 type EditGeneralForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4429,6 +4583,8 @@ func (s EditGeneralForumTopic) ContentType() string {
 // must have the can_manage_topics administrator rights.
 // Returns True on success.
 type CloseGeneralForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4462,6 +4618,8 @@ func (s CloseGeneralForumTopic) ContentType() string {
 // The topic will be automatically unhidden if it was hidden.
 // Returns True on success.
 type ReopenGeneralForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4495,6 +4653,8 @@ func (s ReopenGeneralForumTopic) ContentType() string {
 // The topic will be automatically closed if it was open.
 // Returns True on success.
 type HideGeneralForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4527,6 +4687,8 @@ func (s HideGeneralForumTopic) ContentType() string {
 // The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights.
 // Returns True on success.
 type UnhideGeneralForumTopic struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4560,6 +4722,8 @@ func (s UnhideGeneralForumTopic) ContentType() string {
 // must have the can_pin_messages administrator right in the supergroup.
 // Returns True on success.
 type UnpinAllGeneralForumTopicMessages struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target supergroup (in the format @supergroupusername)
 	ChatId string `json:"chat_id"`
@@ -4596,6 +4760,8 @@ func (s UnpinAllGeneralForumTopicMessages) ContentType() string {
 // For this option to work, you must first create a game for your bot via @BotFather and accept the terms.
 // Otherwise, you may use links like t.me/your_bot?start=XXXX that open your bot with a parameter.
 type AnswerCallbackQuery struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the query to be answered
 	CallbackQueryId string `json:"callback_query_id"`
@@ -4647,6 +4813,8 @@ func (s AnswerCallbackQuery) ContentType() string {
 // Requires administrator rights in the chat.
 // Returns a [objects.UserChatBoosts] object.
 type GetUserChatBoosts struct {
+	gotely.Returns[objects.UserChatBoosts]
+
 	// REQUIRED:
 	// Unique identifier for the chat or username of the channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -4684,6 +4852,8 @@ func (s GetUserChatBoosts) ContentType() string {
 // Use this method to get information about the connection of the bot with a business account.
 // Returns a [objects.BusinessConnection] object on success.
 type GetBusinessConnection struct {
+	gotely.Returns[objects.BusinessConnection]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -4716,6 +4886,8 @@ func (s GetBusinessConnection) ContentType() string {
 // See this manual for more details about bot commands.
 // Returns True on success.
 type SetMyCommands struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// A JSON-serialized list of bot commands to be set as the list of the bot's commands. At most 100 commands can be specified.
 	Commands []objects.BotCommand `json:"commands"`
@@ -4760,6 +4932,8 @@ func (s SetMyCommands) ContentType() string {
 // After deletion, higher level commands will be shown to affected users.
 // Returns True on success.
 type DeleteMyCommands struct {
+	gotely.Returns[bool]
+
 	// A JSON-serialized object, describing scope of users for which the commands are relevant. Defaults to BotCommandScopeDefault.
 	Scope objects.BotCommandScope `json:"scope,omitempty"`
 	// A two-letter ISO 639-1 language code. If empty, commands will be applied to all users from the given scope, for whose language there are no dedicated commands
@@ -4794,6 +4968,8 @@ func (s DeleteMyCommands) ContentType() string {
 // Use this method to get the current list of the bot's commands for the given scope and user language.
 // Returns an Array of [objects.BotCommand] objects. If commands aren't set, an empty list is returned.
 type GetMyCommands struct {
+	gotely.Returns[[]objects.BotCommand]
+
 	// A JSON-serialized object, describing scope of users. Defaults to BotCommandScopeDefault.
 	Scope objects.BotCommandScope `json:"scope,omitempty"`
 	// A two-letter ISO 639-1 language code or an empty string
@@ -4828,6 +5004,8 @@ func (s GetMyCommands) ContentType() string {
 // Use this method to change the bot's name.
 // Returns True on success.
 type SetMyName struct {
+	gotely.Returns[bool]
+
 	// New bot name; 0-64 characters. Pass an empty string to remove the dedicated name for the given language.
 	Name *string `json:"name,omitempty"`
 	// A two-letter ISO 639-1 language code. If empty, the name will be shown to all users for whose language there is no dedicated name.
@@ -4862,6 +5040,8 @@ func (s SetMyName) ContentType() string {
 // Use this method to get the current bot name for the given user language.
 // Returns BotName on success.
 type GetMyName struct {
+	gotely.Returns[objects.BotName]
+
 	// A two-letter ISO 639-1 language code or an empty string
 	LanguageCode *string `json:"language_code,omitempty"`
 }
@@ -4885,6 +5065,8 @@ func (s GetMyName) ContentType() string {
 // Use this method to change the bot's description, which is shown in the chat with the bot if the chat is empty.
 // Returns True on success.
 type SetMyDescription struct {
+	gotely.Returns[bool]
+
 	// New bot description; 0-512 characters. Pass an empty string to remove the dedicated description for the given language.
 	Description *string `json:"description,omitempty"`
 	// A two-letter ISO 639-1 language code. If empty, the description will be applied to all users for whose language there is no dedicated description.
@@ -4919,6 +5101,8 @@ func (s SetMyDescription) ContentType() string {
 // Use this method to get the current bot description for the given user language.
 // Returns [objects.BotDescription] on success.
 type GetMyDescription struct {
+	gotely.Returns[objects.BotDescription]
+
 	// A two-letter ISO 639-1 language code or an empty string
 	LanguageCode *string `json:"language_code,omitempty"`
 }
@@ -4942,6 +5126,8 @@ func (s GetMyDescription) ContentType() string {
 // Use this method to change the bot's short description, which is shown on the bot's profile page and is sent together with the link when users share the bot.
 // Returns True on success.
 type SetMyShortDescription struct {
+	gotely.Returns[bool]
+
 	// New short description for the bot; 0-120 characters. Pass an empty string to remove the dedicated short description for the given language.
 	ShortDescription *string `json:"short_description,omitempty"`
 	// A two-letter ISO 639-1 language code. If empty, the short description will be applied to all users for whose language there is no dedicated short description.
@@ -4976,6 +5162,8 @@ func (s SetMyShortDescription) ContentType() string {
 // Use this method to get the current bot short description for the given user language.
 // Returns [objects.BotShortDescription] on success.
 type GetMyShortDescription struct {
+	gotely.Returns[objects.BotShortDescription]
+
 	// A two-letter ISO 639-1 language code or an empty string
 	LanguageCode *string `json:"language_code,omitempty"`
 }
@@ -4999,6 +5187,8 @@ func (s GetMyShortDescription) ContentType() string {
 // Use this method to change the bot's menu button in a private chat, or the default menu button.
 // Returns True on success.
 type SetChatMenuButton struct {
+	gotely.Returns[bool]
+
 	// Unique identifier for the target private chat. If not specified, default bot's menu button will be changed
 	ChatId *string `json:"chat_id,omitempty"`
 	// A JSON-serialized object for the bot's new menu button. Defaults to MenuButtonDefault
@@ -5038,6 +5228,8 @@ func (s SetChatMenuButton) ContentType() string {
 // Use this method to get the current value of the bot's menu button in a private chat, or the default menu button.
 // Returns [objects.MenuButtonResponse] on success.
 type GetChatMenuButton struct {
+	gotely.Returns[objects.MenuButtonResponse]
+
 	// Unique identifier for the target private chat. If not specified, default bot's menu button will be returned
 	ChatId *int `json:"chat_id,omitempty"`
 }
@@ -5071,6 +5263,8 @@ func (s GetChatMenuButton) ContentType() string {
 // These rights will be suggested to users, but they are free to modify the list before adding the bot.
 // Returns True on success.
 type SetMyDefaultAdministratorRights struct {
+	gotely.Returns[bool]
+
 	// A JSON-serialized object describing new default administrator rights. If not specified, the default administrator rights will be cleared.
 	Rights *objects.ChatAdministratorRights `json:"rights,omitempty"`
 	// Pass True to change the default administrator rights of the bot in channels.
@@ -5097,6 +5291,8 @@ func (s SetMyDefaultAdministratorRights) ContentType() string {
 // Use this method to get the current default administrator rights of the bot.
 // Returns [objects.ChatAdministratorRights] on success.
 type GetMyDefaultAdministratorRights struct {
+	gotely.Returns[objects.ChatAdministratorRights]
+
 	// Pass True to get default administrator rights of the bot in channels.
 	// Otherwise, default administrator rights of the bot for groups and supergroups will be returned.
 	ForChannels *bool `json:"for_channels,omitempty"`
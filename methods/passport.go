@@ -17,6 +17,8 @@ import (
 // For example, if a birthday date seems invalid, a submitted document is blurry, a scan shows evidence of tampering, etc.
 // Supply some details in the error message to make sure the user knows how to correct the issues.
 type SetPassportDataErrors struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// User identifier
 	UserId int `json:"user_id"`
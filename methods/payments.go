@@ -11,6 +11,8 @@ import (
 // Use this method to send invoices.
 // On success, the sent [objects.Message] is returned.
 type SendInvoice struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -157,6 +159,8 @@ func (s SendInvoice) ContentType() string {
 // Use this method to create a link for an invoice.
 // Returns the created invoice link as String on success.
 type CreateInvoiceLink struct {
+	gotely.Returns[string]
+
 	// REQUIRED:
 	// Product name, 1-32 characters
 	Title string `json:"title"`
@@ -285,6 +289,8 @@ func (s CreateInvoiceLink) ContentType() string {
 // the Bot API will send an [objects.Update] with a shipping_query field to the bot. Use this method to reply to shipping queries.
 // On success, True is returned.
 type AnswerShippingQuery struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the query to be answered
 	ShippingQueryId string `json:"shipping_query_id"`
@@ -342,6 +348,8 @@ func (s AnswerShippingQuery) ContentType() string {
 // On success, True is returned.
 // Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
 type AnswerPreCheckoutQuery struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the query to be answered
 	PreCheckoutQueryId string `json:"pre_checkout_query_id"`
@@ -386,6 +394,8 @@ func (s AnswerPreCheckoutQuery) ContentType() string {
 // Returns the bot's Telegram Star transactions in chronological order.
 // On success, returns a [objects.StarTransactions] object.
 type GetStarTransactions struct {
+	gotely.Returns[objects.StarTransactions]
+
 	// Number of transactions to skip in the response
 	Offset *int `json:"offset,omitempty"`
 	// The maximum number of transactions to be retrieved. Values between 1-100 are accepted. Defaults to 100.
@@ -419,6 +429,8 @@ func (s GetStarTransactions) ContentType() string {
 
 // Refunds a successful payment in Telegram Stars. Returns True on success.
 type RefundStarPayment struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Identifier of the user whose payment will be refunded
 	UserId int `json:"user_id"`
@@ -456,6 +468,8 @@ func (s RefundStarPayment) ContentType() string {
 // Allows the bot to cancel or re-enable extension of a subscription paid in Telegram Stars.
 // Returns True on success.
 type EditUserStarSubscription struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Identifier of the user whose subscription will be edited
 	UserId int `json:"user_id"`
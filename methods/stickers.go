@@ -13,6 +13,8 @@ import (
 // Use this method to send static .WEBP, animated .TGS, or video .WEBM stickers.
 // On success, the sent [objects.Message] is returned.
 type SendSticker struct {
+	gotely.Returns[objects.Message]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -152,6 +154,8 @@ func (s SendSticker) ContentType() string {
 // Use this method to get a sticker set.
 // On success, a [objects.StickerSet] object is returned.
 type GetStickerSet struct {
+	gotely.Returns[objects.StickerSet]
+
 	// REQUIRED:
 	// Name of the sticker set
 	Name string `json:"name"`
@@ -183,6 +187,8 @@ func (s GetStickerSet) ContentType() string {
 // Use this method to get information about custom emoji stickers by their identifiers.
 // Returns an Array of [objects.Sticker] objects.
 type GetCustomEmojiStickers struct {
+	gotely.Returns[[]objects.Sticker]
+
 	// REQUIRED:
 	// A JSON-serialized list of custom emoji identifiers.
 	// At most 200 custom emoji identifiers can be specified.
@@ -216,6 +222,8 @@ func (s GetCustomEmojiStickers) ContentType() string {
 // addStickerToSet, or replaceStickerInSet methods (the file can be used multiple times).
 // Returns the uploaded [objects.File] on success.
 type UploadStickerFile struct {
+	gotely.Returns[objects.File]
+
 	// REQUIRED:
 	// User identifier of sticker file owner
 	UserId int `json:"user_id"`
@@ -290,6 +298,8 @@ func (s UploadStickerFile) ContentType() string {
 // The bot will be able to edit the sticker set thus created.
 // Returns True on success.
 type CreateNewStickerSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// User identifier of created sticker set owner
 	UserId int `json:"user_id"`
@@ -415,6 +425,8 @@ func (s CreateNewStickerSet) ContentType() string {
 // Other sticker sets can have up to 120 stickers.
 // Returns True on success.
 type AddStickerToSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// User identifier of sticker set owner
 	UserId int `json:"user_id"`
@@ -485,6 +497,8 @@ func (s AddStickerToSet) ContentType() string {
 // Use this method to move a sticker in a set created by the bot to a specific position.
 // Returns True on success.
 type SetStickerPositionInSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// File identifier of the sticker
 	Sticker string `json:"sticker"`
@@ -522,6 +536,8 @@ func (s SetStickerPositionInSet) ContentType() string {
 // Use this method to delete a sticker from a set created by the bot.
 // Returns True on success.
 type DeleteStickerFromSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	Sticker string `json:"sticker"`
 }
@@ -553,6 +569,8 @@ func (s DeleteStickerFromSet) ContentType() string {
 // The method is equivalent to calling deleteStickerFromSet, then addStickerToSet, then setStickerPositionInSet.
 // Returns True on success.
 type ReplaceStickerInSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// User identifier of the sticker set owner
 	UserId int `json:"user_id"`
@@ -634,6 +652,8 @@ func (s ReplaceStickerInSet) ContentType() string {
 // The sticker must belong to a sticker set created by the bot.
 // Returns True on success.
 type SetStickerEmojiList struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// File identifier of the sticker
 	Sticker string `json:"sticker"`
@@ -671,6 +691,8 @@ func (s SetStickerEmojiList) ContentType() string {
 // Use this method to change search keywords assigned to a regular or custom emoji sticker.
 // The sticker must belong to a sticker set created by the bot. Returns True on success.
 type SetStickerKeywords struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// File identifier of the sticker
 	Sticker string `json:"sticker"`
@@ -711,6 +733,8 @@ func (s SetStickerKeywords) ContentType() string {
 // The sticker must belong to a sticker set that was created by the bot.
 // Returns True on success.
 type SetStickerMaskPosition struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// File identifier of the sticker
 	Sticker string `json:"sticker"`
@@ -750,6 +774,8 @@ func (s SetStickerMaskPosition) ContentType() string {
 
 // Use this method to set the title of a created sticker set. Returns True on success.
 type SetStickerSetTitle struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Sticker set name
 	Name string `json:"name"`
@@ -788,6 +814,8 @@ func (s SetStickerSetTitle) ContentType() string {
 // The format of the thumbnail file must match the format of the stickers in the set.
 // Returns True on success.
 type SetStickerSetThumbnail struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Sticker set name
 	Name string `json:"name"`
@@ -853,6 +881,8 @@ func (s SetStickerSetThumbnail) ContentType() string {
 // Use this method to set the thumbnail of a custom emoji sticker set.
 // Returns True on success.
 type SetCustomEmojiStickerSetThumbnail struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Sticker set name
 	Name string `json:"name"`
@@ -893,6 +923,8 @@ func (s SetCustomEmojiStickerSetThumbnail) ContentType() string {
 // Use this method to delete a sticker set that was created by the bot.
 // Returns True on success.
 type DeleteStickerSet struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Sticker set name
 	Name string `json:"name"`
@@ -924,7 +956,9 @@ func (s DeleteStickerSet) ContentType() string {
 // Returns the list of gifts that can be sent by the bot to users and channel chats.
 // Requires no parameters.
 // Returns a [objects.Gifts] object.
-type GetAvailableGifts struct{}
+type GetAvailableGifts struct {
+	gotely.Returns[objects.Gifts]
+}
 
 func (g GetAvailableGifts) Validate() error {
 	return nil
@@ -14,6 +14,8 @@ import (
 // Note that business messages that were not sent by the bot and
 // do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 type EditMessageText struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// REQUIRED:
 	// New text of the message, 1-4096 characters after entities parsing
 	Text string `json:"text"`
@@ -40,7 +42,7 @@ type EditMessageText struct {
 
 func (e EditMessageText) Validate() error {
 	var err gotely.ErrFailedValidation
-	if len(e.Text) < 1 || len(e.Text) > 4096 {
+	if l := objects.UTF16Len(e.Text); l < 1 || l > objects.MaxTextLen {
 		err = append(err, fmt.Errorf("text parameter must be between 1 and 4096 characters"))
 	}
 	if e.ChatId == nil && e.MessageId == nil {
@@ -92,6 +94,8 @@ func (s EditMessageText) ContentType() string {
 // Note that business messages that were not sent by the bot and
 // do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 type EditMessageCaption struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// Unique identifier of the business connection on behalf of which the message to be edited was sent
 	BusinessConnectionId *string `json:"business_connection_id,omitempty"`
 	// Required if inline_message_id is not specified.
@@ -142,6 +146,9 @@ func (e EditMessageCaption) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if e.Caption != nil && objects.UTF16Len(*e.Caption) > objects.MaxCaptionLen {
+		err = append(err, fmt.Errorf("caption parameter must not be longer than 1024 characters"))
+	}
 	if len(err) > 0 {
 		return err
 	}
@@ -168,6 +175,8 @@ func (s EditMessageCaption) ContentType() string {
 // Note that business messages that were not sent by the bot and
 // do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 type EditMessageMedia struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// REQUIRED:
 	// A JSON-serialized object for a new media content of the message
 	Media objects.InputMedia `json:"media"`
@@ -282,6 +291,8 @@ func (s EditMessageMedia) ContentType() string {
 // A location can be edited until its live_period expires or editing is explicitly disabled by a call to [StopMessageLiveLocation].
 // On success, if the edited message is not an inline message, the edited [objects.Message] is returned, otherwise True is returned.
 type EditMessageLiveLocation struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// REQUIRED:
 	// Latitude of new location
 	Latitude *float64 `json:"latitude"`
@@ -372,6 +383,8 @@ func (s EditMessageLiveLocation) ContentType() string {
 // Use this method to stop updating a live location message before live_period expires.
 // On success, if the message is not an inline message, the edited [objects.Message] is returned, otherwise True is returned.
 type StopMessageLiveLocation struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// Unique identifier of the business connection on behalf of which the message to be edited was sent
 	BusinessConnectionId *string `json:"business_connection_id,omitempty"`
 	// Required if inline_message_id is not specified.
@@ -411,11 +424,25 @@ func (e StopMessageLiveLocation) Validate() error {
 	return nil
 }
 
+func (e StopMessageLiveLocation) Endpoint() string {
+	return "stopMessageLiveLocation"
+}
+
+func (e StopMessageLiveLocation) Reader() io.Reader {
+	return gotely.EncodeJSON(e)
+}
+
+func (e StopMessageLiveLocation) ContentType() string {
+	return "application/json"
+}
+
 // Use this method to edit only the reply markup of messages.
 // On success, if the edited message is not an inline message, the edited [objects.Message] is returned,
 // otherwise True is returned. Note that business messages that were not sent by the bot and
 // do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 type EditMessageReplyMarkup struct {
+	gotely.Returns[objects.MessageOrTrue]
+
 	// Unique identifier of the business connection on behalf of which the message to be edited was sent
 	BusinessConnectionId *string `json:"business_connection_id,omitempty"`
 	// Required if inline_message_id is not specified.
@@ -455,9 +482,23 @@ func (e EditMessageReplyMarkup) Validate() error {
 	return nil
 }
 
+func (e EditMessageReplyMarkup) Endpoint() string {
+	return "editMessageReplyMarkup"
+}
+
+func (e EditMessageReplyMarkup) Reader() io.Reader {
+	return gotely.EncodeJSON(e)
+}
+
+func (e EditMessageReplyMarkup) ContentType() string {
+	return "application/json"
+}
+
 // Use this method to stop a poll which was sent by the bot.
 // On success, the stopped [objects.Poll] is returned.
 type StopPoll struct {
+	gotely.Returns[objects.Poll]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -522,6 +563,8 @@ func (s StopPoll) ContentType() string {
 //
 // Returns True on success.
 type DeleteMessage struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -560,6 +603,8 @@ func (s DeleteMessage) ContentType() string {
 // If some of the specified messages can't be found, they are skipped.
 // Returns True on success.
 type DeleteMessages struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -599,6 +644,8 @@ func (s DeleteMessages) ContentType() string {
 // The gift can't be converted to Telegram Stars by the receiver.
 // Returns True on success.
 type SendGift struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Identifier of the gift
 	GiftId string `json:"gift_id"`
@@ -666,6 +713,8 @@ func (s SendGift) ContentType() string {
 // Gifts a Telegram Premium subscription to the given user.
 // Returns True on success.
 type GiftPremiumSubscription struct {
+	gotely.Returns[bool]
+
 	// Unique identifier of the target user who will receive a Telegram Premium subscription
 	UserId int `json:"user_id"`
 	// Number of months the Telegram Premium subscription will be active for the user; must be one of 3, 6, or 12
@@ -729,6 +778,8 @@ func (g GiftPremiumSubscription) ContentType() string {
 // Verifies a user on behalf of the organization which is represented by the bot.
 // Returns True on success.
 type VerifyUser struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the target user
 	UserId int `json:"user_id"`
@@ -768,6 +819,8 @@ func (s VerifyUser) ContentType() string {
 // Verifies a chat on behalf of the organization which is represented by the bot.
 // Returns True on success.
 type VerifyChat struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -808,6 +861,8 @@ func (s VerifyChat) ContentType() string {
 // Removes verification from a user who is currently verified on behalf of the organization represented by the bot.
 // Returns True on success.
 type RemoveUserVerification struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the target user
 	UserId int `json:"user_id"`
@@ -839,6 +894,8 @@ func (s RemoveUserVerification) ContentType() string {
 // Removes verification from a chat that is currently verified on behalf of the organization represented by the bot.
 // Returns True on success.
 type RemoveChatVerification struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
 	ChatId string `json:"chat_id"`
@@ -871,6 +928,8 @@ func (s RemoveChatVerification) ContentType() string {
 // Requires the can_read_messages business bot right.
 // Returns True on success.
 type ReadBusinessMessage struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection on behalf of which to read the message
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -917,6 +976,8 @@ func (s ReadBusinessMessage) ContentType() string {
 // or the can_delete_all_messages business bot right to delete any message.
 // Returns True on success.
 type DeleteBusinessMessage struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection on behalf of which to delete the messages
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -957,6 +1018,8 @@ func (s DeleteBusinessMessage) ContentType() string {
 // Requires the can_change_name business bot right.
 // Returns True on success.
 type SetBusinessAccountName struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1003,6 +1066,8 @@ func (s SetBusinessAccountName) ContentType() string {
 // Requires the can_change_username business bot right.
 // Returns True on success.
 type SetBusinessAccountUsername struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1043,6 +1108,8 @@ func (s SetBusinessAccountUsername) ContentType() string {
 // Requires the can_change_bio business bot right.
 // Returns True on success.
 type SetBusinessAccountBio struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1083,6 +1150,8 @@ func (s SetBusinessAccountBio) ContentType() string {
 // Requires the can_edit_profile_photo business bot right.
 // Returns True on success.
 type SetBusinessAccountProfilePhoto struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1157,6 +1226,8 @@ func (s SetBusinessAccountProfilePhoto) ContentType() string {
 // Requires the can_edit_profile_photo business bot right.
 // Returns True on success.
 type RemoveBusinessAccountProfilePhoto struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1193,6 +1264,8 @@ func (s RemoveBusinessAccountProfilePhoto) ContentType() string {
 // Requires the can_change_gift_settings business bot right.
 // Returns True on success.
 type SetBusinessAccountGiftSettings struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1231,6 +1304,8 @@ func (s SetBusinessAccountGiftSettings) ContentType() string {
 // Requires the can_view_gifts_and_stars business bot right.
 // Returns [objects.StarAmount] on success.
 type GetBusinessAccountStarBalance struct {
+	gotely.Returns[objects.StarAmount]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1263,6 +1338,8 @@ func (s GetBusinessAccountStarBalance) ContentType() string {
 // Requires the can_transfer_stars business bot right.
 // Returns True on success.
 type TransferBusinessAccountStars struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1300,6 +1377,8 @@ func (s TransferBusinessAccountStars) ContentType() string {
 // Requires the can_view_gifts_and_stars business bot right.
 // Returns [objects.OwnedGifts] on success.
 type GetBusinessAccountGifts struct {
+	gotely.Returns[objects.OwnedGifts]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1356,6 +1435,8 @@ func (s GetBusinessAccountGifts) ContentType() string {
 // Requires the can_convert_gifts_to_stars business bot right.
 // Returns True on success.
 type ConvertGiftToStarts struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1395,6 +1476,8 @@ func (s ConvertGiftToStarts) ContentType() string {
 // Additionally requires the can_transfer_stars business bot right if the upgrade is paid.
 // Returns True on success.
 type UpgradeGift struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1441,6 +1524,8 @@ func (s UpgradeGift) ContentType() string {
 // Requires can_transfer_stars business bot right if the transfer is paid.
 // Returns True on success.
 type TransferGift struct {
+	gotely.Returns[bool]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1490,6 +1575,8 @@ func (s TransferGift) ContentType() string {
 // Requires the can_manage_stories business bot right.
 // Returns [objects.Story] on success.
 type PostStory struct {
+	gotely.Returns[objects.Story]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1624,6 +1711,8 @@ func (s PostStory) ContentType() string {
 // Requires the can_manage_stories business bot right.
 // Returns [objects.Story] on success.
 type EditStory struct {
+	gotely.Returns[objects.Story]
+
 	// REQUIRED:
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
@@ -1737,6 +1826,8 @@ func (s EditStory) ContentType() string {
 // Requires the can_manage_stories business bot right.
 // Returns True on success.
 type DeleteStory struct {
+	gotely.Returns[bool]
+
 	// Unique identifier of the business connection
 	BusinessConnectionId string `json:"business_connection_id"`
 	// Unique identifier of the story to delete
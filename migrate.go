@@ -0,0 +1,110 @@
+package gotely
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// WithChatMigration enables following group migrations.
+// When a request fails because the group has been migrated to a supergroup,
+// and the request body has a ChatId field, the request is re-issued once
+// against the new supergroup identifier from [ResponseParameters.MigrateToChatId].
+//
+// If f is not nil, it is called with the old chat identifier and the new one
+// before re-issuing the request, so the stored identifiers can be updated.
+func WithChatMigration(f func(from string, to int64)) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.FollowChatMigration = true
+		rc.OnChatMigrated = f
+	}
+}
+
+// migrate returns a copy of body targeting the supergroup the group was migrated to,
+// if err reports a migration and body carries a ChatId.
+func (c *Client) migrate(body Method, err error) (Method, bool) {
+	var apiErr ErrTelegramAPIFailedRequest
+	if !errors.As(err, &apiErr) {
+		return nil, false
+	}
+	if apiErr.ResponseParameters == nil || apiErr.ResponseParameters.MigrateToChatId == nil {
+		return nil, false
+	}
+	to := int64(*apiErr.ResponseParameters.MigrateToChatId)
+
+	next, from, ok := withChatId(body, to)
+	if !ok {
+		return nil, false
+	}
+	if c.cfg.OnChatMigrated != nil {
+		c.cfg.OnChatMigrated(from, to)
+	}
+	return next, true
+}
+
+// withChatId returns a copy of body with the ChatId field set to id,
+// as well as the previous value of the field.
+// It reports false if body has no ChatId field of a supported type.
+func withChatId(body Method, id int64) (Method, string, bool) {
+	v := reflect.ValueOf(body)
+	isPtr := v.Kind() == reflect.Pointer
+	if isPtr {
+		if v.IsNil() {
+			return nil, "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, "", false
+	}
+
+	// copying to avoid modifying the original request body
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+
+	f := cp.FieldByName("ChatId")
+	if !f.IsValid() || !f.CanSet() {
+		return nil, "", false
+	}
+	if f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			return nil, "", false
+		}
+		// the pointer is shared with the original request body, so it's replaced instead of being written to
+		ptr := reflect.New(f.Type().Elem())
+		from, ok := setChatId(ptr.Elem(), f.Elem(), id)
+		if !ok {
+			return nil, "", false
+		}
+		f.Set(ptr)
+		return methodOf(cp, isPtr), from, true
+	}
+	from, ok := setChatId(f, f, id)
+	if !ok {
+		return nil, "", false
+	}
+	return methodOf(cp, isPtr), from, true
+}
+
+// setChatId reads the previous chat identifier from old and writes id to f.
+func setChatId(f, old reflect.Value, id int64) (string, bool) {
+	switch f.Kind() {
+	case reflect.String:
+		from := old.String()
+		f.SetString(strconv.FormatInt(id, 10))
+		return from, true
+	case reflect.Int, reflect.Int64:
+		from := fmt.Sprint(old.Int())
+		f.SetInt(id)
+		return from, true
+	}
+	return "", false
+}
+
+func methodOf(v reflect.Value, isPtr bool) Method {
+	if isPtr {
+		return v.Addr().Interface().(Method)
+	}
+	return v.Interface().(Method)
+}
@@ -0,0 +1,20 @@
+package objects
+
+import "unicode/utf16"
+
+const (
+	// MaxTextLen is the maximum length of a message text in UTF-16 code units.
+	MaxTextLen = 4096
+	// MaxCaptionLen is the maximum length of a media caption in UTF-16 code units.
+	MaxCaptionLen = 1024
+)
+
+// UTF16Len returns the length of s in UTF-16 code units,
+// the units the Telegram Bot API measures texts and the offsets of [MessageEntity] in.
+func UTF16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
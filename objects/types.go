@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
+	"path/filepath"
 	"reflect"
 	"strings"
 
@@ -52,7 +54,7 @@ type Update struct {
 	EditedBusinessMessage *Message `json:"edited_business_message,omitempty"`
 
 	// Optional. Messages were deleted from a connected business account
-	DeletedBusinessMessage *BusinessMessagesDeleted `json:"deleted_business_message,omitempty"`
+	DeletedBusinessMessage *BusinessMessagesDeleted `json:"deleted_business_messages,omitempty"`
 
 	// Optional. A reaction to a message was changed by a user.
 	// The bot must be an administrator in the chat and must explicitly specify "message_reaction"
@@ -71,7 +73,7 @@ type Update struct {
 
 	// Optional. The result of an inline query that was chosen by a user and sent to their chat partner.
 	// Please see our documentation on the feedback collecting for details on how to enable these updates for your bot.
-	ChosenInlineQuery *ChosenInlineResult `json:"chosen_inline_query,omitempty"`
+	ChosenInlineQuery *ChosenInlineResult `json:"chosen_inline_result,omitempty"`
 
 	// Optional. New incoming callback query
 	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
@@ -127,7 +129,7 @@ type User struct {
 	LastName *string `json:"last_name,omitempty"`
 
 	// Optional. User's or bot's username
-	UserName *string `json:"user_name,omitempty"`
+	UserName *string `json:"username,omitempty"`
 
 	// Optional. IETF language tag of the user's language
 	LanguageCode *string `json:"language_code,omitempty"`
@@ -168,7 +170,7 @@ type Chat struct {
 	Title *string `json:"title,omitempty"`
 
 	// Optional. Username, for private chats, supergroups and channels if available
-	UserName *string `json:"user_name,omitempty"`
+	UserName *string `json:"username,omitempty"`
 
 	// Optional. First name of the other party in a private chat
 	FirstName *string `json:"first_name,omitempty"`
@@ -191,7 +193,7 @@ type ChatFullInfo struct {
 	// Optional. Title, for supergroups, channels and group chats
 	Title *string `json:"title,omitempty,"`
 	// Optional. Username, for private chats, supergroups and channels if available
-	UserName *string `json:"user_name,omitempty,"`
+	UserName *string `json:"username,omitempty,"`
 	// Optional. First name of the other party in a private chat
 	FirstName *string `json:"first_name,omitempty,"`
 	// Optional. Last name of the other party in a private chat
@@ -493,8 +495,9 @@ type Message struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 }
 
+// IsCommand reports whether the message text starts with a bot command.
 func (m Message) IsCommand() bool {
-	if len(*m.Text) != 0 && m.Entities != nil {
+	if m.Text != nil && len(*m.Text) != 0 && m.Entities != nil {
 		for _, en := range *m.Entities {
 			if !reflect.DeepEqual(en, MessageEntity{}) && en.Offset == 0 &&
 				en.Type == "bot_command" {
@@ -564,6 +567,30 @@ func (m *MaybeInaccessibleMessage) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// This object describes the result of the methods that edit a message.
+// If the edited message is not an inline message, the edited [Message] is returned,
+// otherwise True is returned.
+type MessageOrTrue struct {
+	// The edited message. Nil if the edited message is an inline message
+	Message *Message
+	// True if the request was successful
+	Ok bool
+}
+
+func (m *MessageOrTrue) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "true" {
+		m.Ok = true
+		return nil
+	}
+	var result Message
+	if err := gotely.DecodeJSON(bytes.NewReader(data), &result); err != nil {
+		return err
+	}
+	m.Message = &result
+	m.Ok = true
+	return nil
+}
+
 type MessageEntity struct {
 	//Type of the entity. Currently, can be “mention” (@username), “hashtag” (#hashtag or #hashtag@chatusername),
 	//“cashtag” ($USD or $USD@chatusername), “bot_command” (/start@jobs_bot), “url” (https://telegram.org),
@@ -1710,7 +1737,7 @@ type File struct {
 	// some programming languages may have difficulty/silent defects in interpreting it.
 	// But it has at most 52 significant bits, so a signed 64-bit integer or double-precision float type are safe for storing this value.
 	FileSize *int64 `json:"file_size,omitempty"`
-	// Optional. File path. Use https://gotely.telegram.org/file/bot<token>/<file_path> to get the file.
+	// Optional. File path. Use https://api.telegram.org/file/bot<token>/<file_path> or [gotely.Client.DownloadFile] to get the file.
 	FilePath *string `json:"file_path,omitempty"`
 }
 
@@ -1737,6 +1764,11 @@ type ReplyMarkupInterface interface {
 	replyKeyboardContract()
 }
 
+// MarshalJSON encodes the underlying reply markup object.
+func (r ReplyMarkup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.ReplyMarkupInterface)
+}
+
 // This object represents a custom keyboard with reply options (see Introduction to bots for details and examples).
 // Not supported in channels and for messages sent on behalf of a Telegram Business account.
 type ReplyKeyboardMarkup struct {
@@ -1768,8 +1800,10 @@ func (f ReplyKeyboardMarkup) replyKeyboardContract() {}
 
 func (r ReplyKeyboardMarkup) Validate() error {
 	var err gotely.ErrFailedValidation
-	if len(*r.InputFieldPlaceholder) < 1 || len(*r.InputFieldPlaceholder) > 64 {
-		err = append(err, fmt.Errorf("InputFieldPlaceholder parameter must be between 1 and 64 characters"))
+	if r.InputFieldPlaceholder != nil {
+		if len(*r.InputFieldPlaceholder) < 1 || len(*r.InputFieldPlaceholder) > 64 {
+			err = append(err, fmt.Errorf("InputFieldPlaceholder parameter must be between 1 and 64 characters"))
+		}
 	}
 	for _, row := range r.Keyboard {
 		for _, key := range row {
@@ -1819,10 +1853,10 @@ func (k KeyboardButton) Validate() error {
 	}
 
 	requestsProvided := 0
-	if *k.RequestContact {
+	if k.RequestContact != nil && *k.RequestContact {
 		requestsProvided++
 	}
-	if *k.RequestLocation {
+	if k.RequestLocation != nil && *k.RequestLocation {
 		requestsProvided++
 	}
 	if k.WebApp != nil {
@@ -1890,8 +1924,10 @@ func (k KeyboardButtonRequestUsers) Validate() error {
 	if k.RequestId == 0 {
 		err = append(err, fmt.Errorf("request_id parameter can't be empty"))
 	}
-	if *k.MaxQuantity < 1 || *k.MaxQuantity > 10 {
-		err = append(err, fmt.Errorf("MaxQuantity parameter must be between 1 and 10"))
+	if k.MaxQuantity != nil {
+		if *k.MaxQuantity < 1 || *k.MaxQuantity > 10 {
+			err = append(err, fmt.Errorf("MaxQuantity parameter must be between 1 and 10"))
+		}
 	}
 	if len(err) > 0 {
 		return err
@@ -1985,7 +2021,7 @@ func (f ReplyKeyboardRemove) replyKeyboardContract() {}
 // This object represents an inline keyboard that appears right next to the message it belongs to.
 type InlineKeyboardMarkup struct {
 	// Array of button rows, each represented by an Array of InlineKeyboardButton objects
-	Keyboard [][]InlineKeyboardButton `json:"keyboard"`
+	Keyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
 }
 
 func (f InlineKeyboardMarkup) replyKeyboardContract() {}
@@ -1998,12 +2034,12 @@ func (m InlineKeyboardMarkup) Validate() error {
 				err = append(err, er)
 			}
 			if key.Pay != nil {
-				if i == 0 || j == 0 {
+				if i != 0 || j != 0 {
 					err = append(err, fmt.Errorf("the button with a specified pay parameter must always be the first button at the first row"))
 				}
 			}
 			if key.CallbackGame != nil {
-				if i == 0 || j == 0 {
+				if i != 0 || j != 0 {
 					err = append(err, fmt.Errorf("the button with a specified callback_game parameter must always be the first button at the first row"))
 				}
 			}
@@ -2064,10 +2100,23 @@ func (b InlineKeyboardButton) Validate() error {
 		err = append(err, fmt.Errorf("text parameter can't be empty"))
 	}
 	if b.CallbackData != nil {
-		if len([]byte(*b.CallbackData)) > 64 {
-			err = append(err, fmt.Errorf("callback_data must not be longer than 64 bytes if specified"))
+		if len(*b.CallbackData) < 1 || len(*b.CallbackData) > 64 {
+			err = append(err, fmt.Errorf("callback_data must be between 1 and 64 bytes if specified"))
+		}
+	}
+	fields := 0
+	for _, set := range []bool{
+		b.Url != nil, b.CallbackData != nil, b.WebApp != nil, b.LoginUrl != nil,
+		b.SwitchInlineQuery != nil, b.SwitchInlineQueryCurrentChat != nil, b.SwitchInlineQueryChosenChat != nil,
+		b.CopyText != nil, b.CallbackGame != nil, b.Pay != nil && *b.Pay,
+	} {
+		if set {
+			fields++
 		}
 	}
+	if fields != 1 {
+		err = append(err, fmt.Errorf("exactly one of the optional fields must be used to specify type of the button"))
+	}
 	if b.CopyText != nil {
 		if er := b.CopyText.Validate(); er != nil {
 			err = append(err, er)
@@ -2140,7 +2189,7 @@ type SwitchInlineQueryChosenChat struct {
 // This object represents an inline keyboard button that copies specified text to the clipboard.
 type CopyTextButton struct {
 	// The text to be copied to the clipboard; 1-256 characters
-	Text string
+	Text string `json:"text"`
 }
 
 func (c CopyTextButton) Validate() error {
@@ -4494,6 +4543,37 @@ func (i InputFileFromRemote) WriteTo(mw *multipart.Writer, field string) error {
 	return mw.WriteField(field, string(i))
 }
 
+// InputFileFromPath represents a file stored on the same machine as a local Bot API server.
+// Instead of being uploaded, the file is passed to the server as a file:// URI, so it can be of any size.
+// Can only be used with a local Bot API server, see [gotely.WithLocalServer].
+type InputFileFromPath string
+
+// Validates the file path. The path must be absolute.
+func (i InputFileFromPath) Validate() error {
+	var err gotely.ErrFailedValidation
+	if i == "" {
+		err = append(err, fmt.Errorf("file path can't be empty"))
+	} else if !filepath.IsAbs(string(i)) {
+		err = append(err, fmt.Errorf("file path must be absolute"))
+	}
+	if len(err) > 0 {
+		return err
+	}
+	return nil
+}
+
+func (i InputFileFromPath) WriteTo(mw *multipart.Writer, field string) error {
+	return mw.WriteField(field, LocalFileUri(string(i)))
+}
+
+// LocalFileUri returns the file:// URI for the file with the given absolute path.
+// It can be used with the SetMedia methods of input media to send files stored
+// on the same machine as a local Bot API server.
+func LocalFileUri(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
+
 // This object describes the paid media to be sent. Currently, it can be one of
 //
 // - InputPaidMediaPhoto
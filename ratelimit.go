@@ -0,0 +1,176 @@
+package gotely
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimits defines the minimal intervals between requests sent to the Telegram Bot API.
+// Zero interval disables the corresponding limit.
+type RateLimits struct {
+	// Global is the minimal interval between any two limited requests.
+	Global time.Duration
+	// PrivateChat is the minimal interval between two requests to the same private chat.
+	PrivateChat time.Duration
+	// Group is the minimal interval between two requests to the same group or channel.
+	Group time.Duration
+	// Limited reports whether the request is subject to the limits.
+	// Defaults to [SendsMessage].
+	Limited func(Method) bool
+}
+
+// DefaultRateLimits are the limits published by Telegram:
+// about 30 messages per second overall, 1 message per second to the same private chat
+// and 20 messages per minute to the same group.
+var DefaultRateLimits = RateLimits{
+	Global:      time.Second / 30,
+	PrivateChat: time.Second,
+	Group:       time.Minute / 20,
+}
+
+// RateLimiter schedules outgoing requests so they don't exceed [RateLimits].
+// Only the requests reported by [RateLimits.Limited] that have a ChatId field are limited, and the type of the chat
+// is guessed by its identifier: positive identifiers are private chats,
+// negative identifiers and usernames are groups and channels.
+//
+// Requests exceeding the limits are queued instead of failing with 429 Too Many Requests.
+// A single RateLimiter can be shared between several clients of the same bot.
+type RateLimiter struct {
+	limits RateLimits
+
+	mu     sync.Mutex
+	global time.Time
+	chats  map[string]time.Time
+}
+
+// NewRateLimiter creates a new [RateLimiter] with the given limits.
+// Use [DefaultRateLimits] if unsure.
+func NewRateLimiter(l RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits: l,
+		chats:  make(map[string]time.Time),
+	}
+}
+
+// WithRateLimiter sets the [RateLimiter] used to schedule outgoing requests.
+func WithRateLimiter(l *RateLimiter) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.RateLimiter = l
+	}
+}
+
+// Wait blocks until body can be sent without exceeding the limits, or until ctx is done.
+// If ctx is done before that, the reserved slot is not given back.
+func (r *RateLimiter) Wait(ctx context.Context, body Method) error {
+	limited := r.limits.Limited
+	if limited == nil {
+		limited = SendsMessage
+	}
+	if !limited(body) {
+		return nil
+	}
+	chatId, ok := chatIdOf(body)
+	if !ok {
+		return nil
+	}
+
+	d := time.Until(r.reserve(chatId, time.Now()))
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// reserve returns the time the request to the chat can be sent at
+// and moves the next allowed time forward.
+func (r *RateLimiter) reserve(chatId string, now time.Time) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interval := r.limits.PrivateChat
+	if strings.HasPrefix(chatId, "@") || strings.HasPrefix(chatId, "-") {
+		interval = r.limits.Group
+	}
+
+	// the global slot doesn't depend on the chat,
+	// so waiting for a busy chat doesn't delay the requests to other chats
+	slot := now
+	if r.global.After(slot) {
+		slot = r.global
+	}
+	r.global = slot.Add(r.limits.Global)
+
+	at := slot
+	if next := r.chats[chatId]; next.After(at) {
+		at = next
+	}
+	if interval > 0 {
+		r.chats[chatId] = at.Add(interval)
+	}
+
+	// forgetting chats that are no longer limited
+	if len(r.chats) > 1024 {
+		for id, next := range r.chats {
+			if next.Before(now) {
+				delete(r.chats, id)
+			}
+		}
+	}
+	return at
+}
+
+// SendsMessage reports whether body sends a message to a chat:
+// the methods starting with "send", except sendChatAction, and the methods forwarding and copying messages.
+func SendsMessage(body Method) bool {
+	e := body.Endpoint()
+	switch {
+	case e == "sendChatAction":
+		return false
+	case strings.HasPrefix(e, "send"), strings.HasPrefix(e, "forwardMessage"), strings.HasPrefix(e, "copyMessage"):
+		return true
+	}
+	return false
+}
+
+// chatIdOf returns the value of the ChatId field of body.
+// It reports false if body has no such field or it's empty.
+func chatIdOf(body Method) (string, bool) {
+	v := reflect.ValueOf(body)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	f := v.FieldByName("ChatId")
+	if !f.IsValid() {
+		return "", false
+	}
+	if f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			return "", false
+		}
+		f = f.Elem()
+	}
+	switch f.Kind() {
+	case reflect.String:
+		return f.String(), f.String() != ""
+	case reflect.Int, reflect.Int64:
+		return fmt.Sprint(f.Int()), f.Int() != 0
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+package gotely_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/methods"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := gotely.NewRateLimiter(gotely.RateLimits{PrivateChat: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		if err := rl.Wait(ctx, methods.SendMessage{ChatId: "42", Text: "hello"}); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if took := time.Since(start); took < 100*time.Millisecond {
+		t.Fatalf("expected requests to the same chat to be delayed, took %s", took)
+	}
+
+	start = time.Now()
+	if err := rl.Wait(ctx, methods.SendMessage{ChatId: "43", Text: "hello"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := rl.Wait(ctx, methods.GetMe{}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if took := time.Since(start); took > 20*time.Millisecond {
+		t.Fatalf("expected requests to other chats not to be delayed, took %s", took)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := rl.Wait(ctx, methods.SendMessage{ChatId: "43", Text: "hello"}); err == nil {
+		t.Fatal("expected an error on canceled context")
+	}
+}
+
+func TestRateLimiterGroup(t *testing.T) {
+	rl := gotely.NewRateLimiter(gotely.RateLimits{PrivateChat: time.Hour, Group: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	for _, chatId := range []string{"-1001234567890", "@mychannel"} {
+		start := time.Now()
+		for range 3 {
+			if err := rl.Wait(ctx, methods.SendMessage{ChatId: chatId, Text: "hello"}); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+		if took := time.Since(start); took < 100*time.Millisecond || took > time.Second {
+			t.Fatalf("expected requests to %s to be delayed by the group limit, took %s", chatId, took)
+		}
+	}
+}
+
+func TestRateLimiterSkipsOtherMethods(t *testing.T) {
+	rl := gotely.NewRateLimiter(gotely.RateLimits{Global: time.Hour, PrivateChat: time.Hour, Group: time.Hour})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		for _, m := range []gotely.Method{
+			methods.GetChatMember{ChatId: "-1001234567890", UserId: 42},
+			methods.SendChatAction{ChatId: "42", Action: "typing"},
+			methods.DeleteMessage{ChatId: "42", MessageId: 1},
+		} {
+			if err := rl.Wait(ctx, m); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+	}
+	// the chat actions don't delay the message
+	if err := rl.Wait(ctx, methods.SendMessage{ChatId: "42", Text: "hello"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if took := time.Since(start); took > 20*time.Millisecond {
+		t.Fatalf("expected requests not sending messages to pass through, took %s", took)
+	}
+}
+
+func TestRateLimiterBusyChat(t *testing.T) {
+	rl := gotely.NewRateLimiter(gotely.RateLimits{Global: 20 * time.Millisecond, Group: time.Hour})
+	ctx := context.Background()
+
+	if err := rl.Wait(ctx, methods.SendMessage{ChatId: "-100", Text: "hello"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// held by the group limit
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := rl.Wait(short, methods.SendMessage{ChatId: "-100", Text: "hello"}); err == nil {
+		t.Fatal("expected the second message to the group to be delayed")
+	}
+
+	ctx, cancel = context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := rl.Wait(ctx, methods.SendMessage{ChatId: "42", Text: "hello"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if took := time.Since(start); took > 200*time.Millisecond {
+		t.Fatalf("expected the message to another chat to wait only for the global limit, took %s", took)
+	}
+}
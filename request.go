@@ -1,10 +1,8 @@
 package gotely
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -55,7 +53,7 @@ type Method interface {
 }
 
 // RequestOption represents a function that modifies `RequestConfig`.
-// It is used to customize request settings when calling `SendRequestWith`.
+// It is used to customize request settings when calling `SendRequestWith` or `NewClient`.
 type RequestOption func(*RequestConfig)
 
 // RequestConfig defines configuration options for sending a request to the Telegram Bot API.
@@ -68,8 +66,29 @@ type RequestConfig struct {
 	// where the first placeholder is replaced by the bot token and the second by the API method.
 	// Use `%s` placeholders to properly insert the token and API method.
 	ApiUrl string
-
+	// Context is the default context for sending requests.
+	// Defaults to `context.Background()`.
 	Context context.Context
+	// Retry is the policy for retrying failed requests.
+	// Defaults to nil, meaning that failed requests are not retried.
+	Retry *RetryPolicy
+	// FollowChatMigration enables re-issuing requests against the new supergroup
+	// if the target group has been migrated to a supergroup.
+	// Defaults to false.
+	FollowChatMigration bool
+	// OnChatMigrated is called every time a request is re-issued against the new supergroup.
+	OnChatMigrated func(from string, to int64)
+	// RateLimiter schedules outgoing requests to stay within the Telegram Bot API limits.
+	// Defaults to nil, meaning that requests are sent immediately.
+	RateLimiter *RateLimiter
+	// Interceptors wrap every attempt to send a request, the first one being the outermost.
+	Interceptors []Interceptor
+	// LocalServer is true if the client works with a local Bot API server started with the --local flag.
+	LocalServer bool
+	// LocalServerDir is the working directory of the local Bot API server.
+	LocalServerDir string
+	// LocalFilesDir is the directory where LocalServerDir is available for the bot.
+	LocalFilesDir string
 }
 
 // WithClient sets a custom HTTP client for `SendRequestWith`.
@@ -88,6 +107,7 @@ func WithUrl(url string) RequestOption {
 	}
 }
 
+// WithContext sets the default context for `SendRequestWith`.
 func WithContext(ctx context.Context) RequestOption {
 	return func(rc *RequestConfig) {
 		rc.Context = ctx
@@ -101,7 +121,10 @@ var defaultReqCfg = RequestConfig{
 }
 
 func makeReqCfg(opts ...RequestOption) RequestConfig {
-	cfg := defaultReqCfg
+	return applyReqOpts(defaultReqCfg, opts...)
+}
+
+func applyReqOpts(cfg RequestConfig, opts ...RequestOption) RequestConfig {
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -164,48 +187,8 @@ func SendRequest(body Method, token string, dest any) error {
 //	}
 //	// now we can do something with the result
 func SendRequestWith(body Method, token string, dest any, opts ...RequestOption) error {
-	if err := body.Validate(); err != nil {
-		return err
-	}
-	if token == "" {
-		return fmt.Errorf("API token can't be empty")
-	}
-
-	cfg := makeReqCfg(opts...)
-
-	url := formatUrl(cfg.ApiUrl, token, body.Endpoint())
-	// its important to call Reader() before using ContentType()
-	// since content-type boundary is generated inside Reader() and stored inside of a struct
-	req, err := http.NewRequestWithContext(cfg.Context, http.MethodPost, url, body.Reader())
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", body.ContentType())
-
-	resp, err := cfg.Client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	var result ApiResponse
-	if err := DecodeJSON(resp.Body, &result); err != nil {
-		return err
-	}
-
-	if !result.Ok {
-		return ErrTelegramAPIFailedRequest{
-			Code:               *result.ErrorCode,
-			Description:        *result.Description,
-			ResponseParameters: &ResponseParameters{},
-		}
-	}
-	// not writing any results if destination is nil
-	// not returning any errors because the request itself was successful
-	if dest == nil {
-		return nil
-	}
-	return json.NewDecoder(bytes.NewReader(result.Result)).Decode(dest)
+	c := NewClient(token, opts...)
+	return c.Do(c.cfg.Context, body, dest)
 }
 
 func formatUrl(template, token, method string) string {
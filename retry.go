@@ -0,0 +1,122 @@
+package gotely
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy describes how failed requests are retried.
+//
+// If the Telegram Bot API responds with 429 Too Many Requests,
+// the request is repeated after the number of seconds specified in [ResponseParameters.RetryAfter].
+// Server errors (5xx) and network errors are retried with a jittered exponential backoff.
+// Any other error is returned immediately.
+//
+// Note that files uploaded with [objects.InputFileFromReader] are read only once,
+// so such requests can't be retried reliably unless the reader can be read again.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry of a server or network error.
+	// It is doubled with every next attempt.
+	BaseDelay time.Duration
+	// MaxDelay limits the delay between attempts. Zero means no limit.
+	// The delay requested by the Telegram Bot API with retry_after is never shortened:
+	// if it's longer than MaxDelay, the request isn't retried.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable [RetryPolicy] for most bots.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   time.Minute,
+}
+
+// WithRetry enables retrying failed requests according to the policy p.
+// Use [DefaultRetryPolicy] if unsure.
+func WithRetry(p RetryPolicy) RequestOption {
+	return func(rc *RequestConfig) {
+		rc.Retry = &p
+	}
+}
+
+// do calls send until it succeeds, returns an error that shouldn't be retried
+// or the number of attempts is exceeded.
+func (p RetryPolicy) do(ctx context.Context, send func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := send()
+		if err == nil || attempt >= p.MaxRetries {
+			return err
+		}
+		delay, ok := p.Delay(attempt, err)
+		if !ok {
+			return err
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
+// Delay reports whether the request that failed with err should be retried,
+// and how long to wait before the next attempt, counting attempts from zero.
+func (p RetryPolicy) Delay(attempt int, err error) (time.Duration, bool) {
+	var apiErr ErrTelegramAPIFailedRequest
+	if errors.As(err, &apiErr) {
+		if apiErr.Code == http.StatusTooManyRequests {
+			if apiErr.ResponseParameters != nil && apiErr.ResponseParameters.RetryAfter != nil {
+				// retrying earlier would only get another 429
+				d := time.Duration(*apiErr.ResponseParameters.RetryAfter) * time.Second
+				return d, p.MaxDelay <= 0 || d <= p.MaxDelay
+			}
+			return p.Backoff(attempt), true
+		}
+		if apiErr.Code >= http.StatusInternalServerError {
+			return p.Backoff(attempt), true
+		}
+		return 0, false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return p.Backoff(attempt), true
+	}
+	return 0, false
+}
+
+// Backoff returns the jittered exponential delay before the next attempt
+// after a server or network error, counting attempts from zero.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for range attempt {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	d = p.limit(d)
+	if d <= 0 {
+		return 0
+	}
+	// adding jitter to avoid retrying in lockstep with other clients
+	return d/2 + rand.N(d/2+1)
+}
+
+func (p RetryPolicy) limit(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
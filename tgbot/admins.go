@@ -0,0 +1,166 @@
+package tgbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+)
+
+// Admins caches the administrators of chats, requesting them with [methods.GetChatAdministrators]
+// at most once per chat during the lifetime of the cache:
+// concurrent calls for a chat that isn't cached wait for a single request.
+// It's safe for concurrent use.
+//
+// Example:
+//
+//	admins := tgbot.NewAdmins(client, 5*time.Minute)
+//	r.OnCommandContext("ban", "", func(c *tgbot.Context, cmd tgbot.Command) error {
+//		ok, err := admins.Sender(c)
+//		if err != nil || !ok {
+//			return err
+//		}
+//		// banning the user
+//		return nil
+//	})
+type Admins struct {
+	client *gotely.Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	chats   map[int64]admins
+	loading map[int64]*adminsRequest
+}
+
+type admins struct {
+	ids     []int64
+	expires time.Time
+}
+
+// adminsRequest is the request for the administrators of a chat shared by concurrent calls.
+type adminsRequest struct {
+	done chan struct{}
+	ids  []int64
+	err  error
+}
+
+// NewAdmins creates a new empty [Admins] cache requesting the administrators with c,
+// and keeping them for ttl.
+func NewAdmins(c *gotely.Client, ttl time.Duration) *Admins {
+	return &Admins{
+		client:  c,
+		ttl:     ttl,
+		chats:   make(map[int64]admins),
+		loading: make(map[int64]*adminsRequest),
+	}
+}
+
+// IsAdmin reports whether the user is an administrator or the creator of the chat.
+// The administrators are requested with ctx if they're not cached or the cache has expired.
+func (a *Admins) IsAdmin(ctx context.Context, chatId, userId int64) (bool, error) {
+	ids, err := a.admins(ctx, chatId)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(ids, userId), nil
+}
+
+// admins returns the administrators of the chat, requesting them if they're not cached,
+// or waiting for the request made by another call.
+func (a *Admins) admins(ctx context.Context, chatId int64) ([]int64, error) {
+	for {
+		now := time.Now()
+		a.mu.Lock()
+		if cached, ok := a.chats[chatId]; ok && now.Before(cached.expires) {
+			a.mu.Unlock()
+			return cached.ids, nil
+		}
+		if req, ok := a.loading[chatId]; ok {
+			a.mu.Unlock()
+			select {
+			case <-req.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			// the request made with the context of another call could be canceled with it
+			if errors.Is(req.err, context.Canceled) || errors.Is(req.err, context.DeadlineExceeded) {
+				continue
+			}
+			return req.ids, req.err
+		}
+		req := &adminsRequest{done: make(chan struct{})}
+		a.loading[chatId] = req
+		a.mu.Unlock()
+
+		req.ids, req.err = a.request(ctx, chatId)
+
+		a.mu.Lock()
+		delete(a.loading, chatId)
+		if req.err == nil {
+			a.chats[chatId] = admins{ids: req.ids, expires: now.Add(a.ttl)}
+			// forgetting chats that are no longer cached
+			if len(a.chats) > 1024 {
+				for id, c := range a.chats {
+					if c.expires.Before(now) {
+						delete(a.chats, id)
+					}
+				}
+			}
+		}
+		a.mu.Unlock()
+		close(req.done)
+		return req.ids, req.err
+	}
+}
+
+// request requests the ids of the administrators and the creator of the chat.
+func (a *Admins) request(ctx context.Context, chatId int64) ([]int64, error) {
+	members, err := gotely.Call(ctx, a.client, methods.GetChatAdministrators{ChatId: fmt.Sprint(chatId)})
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, m := range members {
+		switch {
+		case m.Owner != nil:
+			ids = append(ids, m.Owner.User.Id)
+		case m.Administrator != nil:
+			ids = append(ids, m.Administrator.User.Id)
+		}
+	}
+	return ids, nil
+}
+
+// Sender reports whether the update was caused by an administrator or the creator of the chat,
+// requesting the administrators with c if needed, so the request is canceled when the bot is stopped.
+// It reports false for private chats and the updates without a chat or a sender.
+func (a *Admins) Sender(c *Context) (bool, error) {
+	chat, user := ChatOf(c.Update), SenderOf(c.Update)
+	if chat == nil || user == nil || chat.Type == "private" {
+		return false, nil
+	}
+	return a.IsAdmin(c, chat.Id, user.Id)
+}
+
+// Filter returns a [Filter] matching the updates caused by an administrator or the creator of the chat.
+// Filters don't receive the context of the update, so the administrators are requested
+// with a context canceled after timeout. Updates are not matched if the request fails.
+// Use [Admins.Sender] in the handler to request them with the context of the update instead.
+func (a *Admins) Filter(timeout time.Duration) Filter {
+	return func(upd objects.Update) bool {
+		chat, user := ChatOf(upd), SenderOf(upd)
+		if chat == nil || user == nil || chat.Type == "private" {
+			return false
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		ok, err := a.IsAdmin(ctx, chat.Id, user.Id)
+		return err == nil && ok
+	}
+}
@@ -0,0 +1,80 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+func TestAdmins(t *testing.T) {
+	requests := 0
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			if endpoint != "getChatAdministrators" {
+				t.Fatalf("unexpected request %s", endpoint)
+			}
+			requests++
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[
+				{"status":"creator","user":{"id":1,"is_bot":false,"first_name":"Owner"},"is_anonymous":false},
+				{"status":"administrator","user":{"id":2,"is_bot":false,"first_name":"Admin"},"can_be_edited":false}
+			]`)}, nil
+		}
+	}))
+
+	message := func(userId int64) objects.Update {
+		return objects.Update{Message: &objects.Message{
+			Chat: objects.Chat{Id: -100, Type: "supergroup"},
+			From: &objects.User{Id: userId},
+		}}
+	}
+	fromAdmin := tgbot.NewAdmins(client, time.Minute).Filter(time.Second)
+	for userId, expected := range map[int64]bool{1: true, 2: true, 3: false} {
+		if fromAdmin(message(userId)) != expected {
+			t.Fatalf("expected %v for user %d", expected, userId)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected the administrators to be requested once, got %d requests", requests)
+	}
+}
+
+func TestAdminsConcurrent(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			requests.Add(1)
+			<-release
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[
+				{"status":"creator","user":{"id":1,"is_bot":false,"first_name":"Owner"},"is_anonymous":false}
+			]`)}, nil
+		}
+	}))
+
+	admins := tgbot.NewAdmins(client, time.Minute)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := admins.IsAdmin(context.Background(), -100, 1)
+			if err != nil || !ok {
+				t.Errorf("expected user 1 to be an administrator, got %v, %v", ok, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected the administrators to be requested once, got %d requests", n)
+	}
+}
@@ -0,0 +1,381 @@
+package callback
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bigelle/gotely/keyboard"
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+// MaxDataLen is the maximum length of callback data in bytes.
+const MaxDataLen = 64
+
+// separators following the prefix
+const (
+	plain      = ':'
+	compressed = '~'
+	stored     = '#'
+
+	separators = string(plain) + string(compressed) + string(stored)
+)
+
+const (
+	// length of the keys in the store, base64-encoded
+	keyLen = 16
+	// maximum length of decompressed data
+	maxPayloadLen = 4096
+)
+
+var (
+	// ErrTooLong is returned when the encoded value is longer than [MaxDataLen] even compressed,
+	// and the codec has no [Store].
+	ErrTooLong = errors.New("callback data is longer than 64 bytes")
+	// ErrPrefixMismatch is returned when decoding callback data that wasn't encoded by the codec.
+	ErrPrefixMismatch = errors.New("callback data has a different prefix")
+	// ErrNotFound is returned when decoding callback data kept in the store, if the store doesn't have it,
+	// for example, if it has expired or the bot was restarted with a [MemoryStore].
+	ErrNotFound = errors.New("callback data is not found in the store")
+)
+
+// Codec packs values of type T into callback data starting with a prefix,
+// and decodes them back from callback queries.
+//
+// T is either a struct or a string, bool or numeric type. Exported fields of a struct are encoded
+// in the order of declaration, separated with ":", for example "page:next:42",
+// so the values encoded before remain decodable if new fields are added to the end of the struct.
+// Fields with the tag `callback:"-"` are skipped. Fields of other types are not supported.
+//
+// Example:
+//
+//	type Page struct {
+//		Action string
+//		Id     int
+//	}
+//
+//	pages, err := callback.New[Page]("page")
+//	if err != nil {
+//		// handling the error
+//	}
+//	btn, err := pages.Button(ctx, "Next", Page{Action: "next", Id: 42})
+//	if err != nil {
+//		// handling the error
+//	}
+//
+//	r := tgbot.NewRouter()
+//	pages.Handle(r, func(c *tgbot.Context, p Page) error {
+//		// handling the button press
+//		return c.Answer("")
+//	})
+type Codec[T any] struct {
+	prefix string
+	store  Store
+	// indexes of the encoded fields, or a single nil index if T isn't a struct
+	fields [][]int
+}
+
+// New creates a new [Codec] encoding values with prefix.
+// The prefix must be unique among the codecs used by the bot, and must not contain ":", "~" or "#".
+// It returns an error if the prefix is not valid or T has fields of unsupported types.
+func New[T any](prefix string, opts ...Option) (*Codec[T], error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("prefix can't be empty")
+	}
+	if strings.ContainsAny(prefix, separators) {
+		return nil, fmt.Errorf("prefix %q must not contain %q, %q or %q", prefix, plain, compressed, stored)
+	}
+	if len(prefix) > MaxDataLen-1-keyLen {
+		return nil, fmt.Errorf("prefix %q must not be longer than %d bytes", prefix, MaxDataLen-1-keyLen)
+	}
+
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cd := &Codec[T]{prefix: prefix, store: cfg.store}
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		if !supported(t) {
+			return nil, fmt.Errorf("type %s can't be encoded into callback data", t)
+		}
+		cd.fields = [][]int{nil}
+		return cd, nil
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("callback") == "-" {
+			continue
+		}
+		if !supported(f.Type) {
+			return nil, fmt.Errorf("field %s of type %s can't be encoded into callback data", f.Name, f.Type)
+		}
+		cd.fields = append(cd.fields, f.Index)
+	}
+	return cd, nil
+}
+
+type config struct {
+	store Store
+}
+
+type Option func(*config)
+
+// WithStore sets the store keeping the data that is longer than [MaxDataLen] even compressed.
+// Without a store, encoding such values fails with [ErrTooLong].
+func WithStore(s Store) Option {
+	return func(c *config) {
+		c.store = s
+	}
+}
+
+func supported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Encode packs v into callback data.
+// If it's longer than [MaxDataLen], it's compressed, and if it still doesn't fit, it's kept in the store,
+// leaving only the key in the callback data.
+func (cd *Codec[T]) Encode(ctx context.Context, v T) (string, error) {
+	payload := cd.pack(v)
+	data := cd.prefix + string(plain) + payload
+	if len(data) <= MaxDataLen {
+		return data, nil
+	}
+
+	z, err := deflate(payload)
+	if err != nil {
+		return "", err
+	}
+	if len(cd.prefix)+1+len(z) <= MaxDataLen {
+		return cd.prefix + string(compressed) + z, nil
+	}
+
+	if cd.store == nil {
+		return "", fmt.Errorf("%w: %q", ErrTooLong, data)
+	}
+	sum := sha256.Sum256([]byte(data))
+	key := base64.RawURLEncoding.EncodeToString(sum[:keyLen*3/4])
+	if err := cd.store.Set(ctx, key, []byte(payload)); err != nil {
+		return "", err
+	}
+	return cd.prefix + string(stored) + key, nil
+}
+
+// Decode unpacks the value encoded by [Codec.Encode] from data.
+// It returns [ErrPrefixMismatch] if data wasn't encoded by the codec.
+func (cd *Codec[T]) Decode(ctx context.Context, data string) (T, error) {
+	var v T
+	rest, ok := strings.CutPrefix(data, cd.prefix)
+	if !ok || rest == "" {
+		return v, ErrPrefixMismatch
+	}
+
+	payload := rest[1:]
+	switch rest[0] {
+	case plain:
+	case compressed:
+		var err error
+		if payload, err = inflate(payload); err != nil {
+			return v, fmt.Errorf("can't decompress callback data %q: %w", data, err)
+		}
+	case stored:
+		if cd.store == nil {
+			return v, fmt.Errorf("callback data %q is kept in a store, but the codec has no store", data)
+		}
+		b, ok, err := cd.store.Get(ctx, payload)
+		if err != nil {
+			return v, err
+		}
+		if !ok {
+			return v, fmt.Errorf("%w: %q", ErrNotFound, data)
+		}
+		payload = string(b)
+	default:
+		return v, ErrPrefixMismatch
+	}
+
+	if err := cd.unpack(payload, &v); err != nil {
+		return v, fmt.Errorf("can't decode callback data %q: %w", data, err)
+	}
+	return v, nil
+}
+
+// Button creates an inline keyboard button with v encoded as its callback data.
+func (cd *Codec[T]) Button(ctx context.Context, text string, v T) (objects.InlineKeyboardButton, error) {
+	data, err := cd.Encode(ctx, v)
+	if err != nil {
+		return objects.InlineKeyboardButton{}, err
+	}
+	return keyboard.Callback(text, data), nil
+}
+
+// Match reports whether the update is a callback query with data encoded by the codec.
+// It can be used as a [tgbot.Filter].
+func (cd *Codec[T]) Match(upd objects.Update) bool {
+	q := upd.CallbackQuery
+	if q == nil || q.Data == nil {
+		return false
+	}
+	rest, ok := strings.CutPrefix(*q.Data, cd.prefix)
+	return ok && rest != "" && strings.IndexByte(separators, rest[0]) >= 0
+}
+
+// Handle registers in r a handler for the callback queries with data encoded by the codec,
+// receiving the decoded value. Errors from decoding the data are returned without calling h.
+func (cd *Codec[T]) Handle(r *tgbot.Router, h func(*tgbot.Context, T) error) {
+	r.HandleContext(cd.Match, func(c *tgbot.Context) error {
+		v, err := cd.Decode(c, *c.Update.CallbackQuery.Data)
+		if err != nil {
+			return err
+		}
+		return h(c, v)
+	})
+}
+
+var escaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// pack encodes the fields of v, omitting the trailing zero values.
+func (cd *Codec[T]) pack(v T) string {
+	rv := reflect.ValueOf(&v).Elem()
+	parts := make([]string, len(cd.fields))
+	for i, idx := range cd.fields {
+		parts[i] = format(field(rv, idx))
+	}
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, string(plain))
+}
+
+func (cd *Codec[T]) unpack(payload string, v *T) error {
+	if payload == "" {
+		return nil
+	}
+	parts := strings.Split(payload, string(plain))
+	if len(parts) > len(cd.fields) {
+		return fmt.Errorf("expected at most %d fields, got %d", len(cd.fields), len(parts))
+	}
+	rv := reflect.ValueOf(v).Elem()
+	for i, s := range parts {
+		if err := parse(s, field(rv, cd.fields[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func field(v reflect.Value, idx []int) reflect.Value {
+	if idx == nil {
+		return v
+	}
+	return v.FieldByIndex(idx)
+}
+
+// format encodes v, returning an empty string for the zero value.
+func format(v reflect.Value) string {
+	if v.IsZero() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return escaper.Replace(v.String())
+	case reflect.Bool:
+		return "1"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+}
+
+func parse(s string, v reflect.Value) error {
+	if s == "" {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		u, err := url.PathUnescape(s)
+		if err != nil {
+			return err
+		}
+		v.SetString(u)
+	case reflect.Bool:
+		if s != "1" {
+			return fmt.Errorf("invalid bool %q", s)
+		}
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+func deflate(payload string) (string, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(payload)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func inflate(s string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	r := flate.NewReader(bytes.NewReader(b))
+	defer r.Close()
+	// callback data comes from clients, so the size is limited
+	payload, err := io.ReadAll(io.LimitReader(r, maxPayloadLen+1))
+	if err != nil {
+		return "", err
+	}
+	if len(payload) > maxPayloadLen {
+		return "", fmt.Errorf("decompressed data is longer than %d bytes", maxPayloadLen)
+	}
+	return string(payload), nil
+}
@@ -0,0 +1,138 @@
+package callback_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+	"github.com/bigelle/gotely/tgbot/callback"
+)
+
+type page struct {
+	Action string
+	Id     int
+	Draft  bool
+}
+
+func TestCodec(t *testing.T) {
+	ctx := context.Background()
+	pages, err := callback.New[page]("page")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := pages.Encode(ctx, page{Action: "next:1", Id: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != "page:next%3A1:42" {
+		t.Fatalf("unexpected callback data %q", data)
+	}
+	p, err := pages.Decode(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != (page{Action: "next:1", Id: 42}) {
+		t.Fatalf("unexpected decoded value %+v", p)
+	}
+	if _, err := pages.Decode(ctx, "pages:1"); !errors.Is(err, callback.ErrPrefixMismatch) {
+		t.Fatalf("expected ErrPrefixMismatch, got %v", err)
+	}
+
+	// compressible data
+	long := page{Action: strings.Repeat("next", 20)}
+	data, err = pages.Encode(ctx, long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > callback.MaxDataLen || !strings.HasPrefix(data, "page~") {
+		t.Fatalf("expected compressed callback data, got %q", data)
+	}
+	if p, err := pages.Decode(ctx, data); err != nil || p != long {
+		t.Fatalf("expected %+v, got %+v, %v", long, p, err)
+	}
+
+	// data that doesn't fit even compressed
+	random := make([]byte, 40)
+	rand.Read(random)
+	long = page{Action: hex.EncodeToString(random)}
+	if _, err := pages.Encode(ctx, long); !errors.Is(err, callback.ErrTooLong) {
+		t.Fatalf("expected ErrTooLong, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "callbacks.json")
+	store, err := callback.NewFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, _ = callback.New[page]("page", callback.WithStore(store))
+	data, err = pages.Encode(ctx, long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > callback.MaxDataLen || !strings.HasPrefix(data, "page#") {
+		t.Fatalf("expected a key of the stored data, got %q", data)
+	}
+	// after a restart
+	store.Close()
+	store, err = callback.NewFileStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	pages, _ = callback.New[page]("page", callback.WithStore(store))
+	if p, err := pages.Decode(ctx, data); err != nil || p != long {
+		t.Fatalf("expected %+v, got %+v, %v", long, p, err)
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	pages, _ := callback.New[page]("page", callback.WithStore(callback.NewMemoryStore(10*time.Millisecond)))
+	random := make([]byte, 40)
+	rand.Read(random)
+	data, err := pages.Encode(ctx, page{Action: hex.EncodeToString(random)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := pages.Decode(ctx, data); !errors.Is(err, callback.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for expired data, got %v", err)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	pages, _ := callback.New[page]("page")
+	ids, _ := callback.New[int]("p")
+
+	var got []string
+	r := tgbot.NewRouter()
+	pages.Handle(r, func(_ *tgbot.Context, p page) error {
+		got = append(got, "page "+p.Action)
+		return nil
+	})
+	ids.Handle(r, func(_ *tgbot.Context, id int) error {
+		if id != 7 {
+			t.Errorf("expected id 7, got %d", id)
+		}
+		got = append(got, "id")
+		return nil
+	})
+
+	for _, data := range []string{"p:7", "page:prev", "pager:1"} {
+		upd := objects.Update{CallbackQuery: &objects.CallbackQuery{Id: "1", Data: &data}}
+		if err := r.OnUpdate(upd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(got, ",") != "id,page prev" {
+		t.Fatalf("unexpected handled callbacks %q", got)
+	}
+}
@@ -0,0 +1,6 @@
+// This package provides a codec packing Go values into the callback data of inline keyboard buttons,
+// within the limit of 64 bytes, and decoding them back from callback queries.
+// Data exceeding the limit is compressed or, if it still doesn't fit, kept in a [Store].
+//
+// Licensed under the MIT License. See LICENSE file for details.
+package callback
@@ -0,0 +1,217 @@
+package callback
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bigelle/gotely/internal/atomicfile"
+)
+
+// Store keeps the callback data that doesn't fit into a button, even compressed.
+// The keys are derived from the data, so the same data is always stored with the same key.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the data stored with key.
+	// It reports false if there is no such data.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores data with key.
+	Set(ctx context.Context, key string, data []byte) error
+}
+
+type entry struct {
+	Key     string    `json:"key"`
+	Data    []byte    `json:"data"`
+	Expires time.Time `json:"expires"`
+}
+
+// entries keeps the data of the stores in memory, removing it after ttl.
+type entries struct {
+	ttl  time.Duration
+	data map[string]entry
+	// number of entries after which the expired ones are removed
+	sweepAt int
+}
+
+func newEntries(ttl time.Duration) entries {
+	return entries{ttl: ttl, data: make(map[string]entry), sweepAt: 1024}
+}
+
+func (e *entries) get(key string, now time.Time) ([]byte, bool) {
+	en, ok := e.data[key]
+	if !ok || now.After(en.Expires) {
+		return nil, false
+	}
+	return en.Data, true
+}
+
+// stale reports whether key has to be stored again:
+// it's not stored, or it expires sooner than in half of the lifetime.
+func (e *entries) stale(key string, now time.Time) bool {
+	en, ok := e.data[key]
+	return !ok || en.Expires.Before(now.Add(e.ttl/2))
+}
+
+func (e *entries) put(en entry, now time.Time) {
+	e.data[en.Key] = en
+	if len(e.data) >= e.sweepAt {
+		for key, en := range e.data {
+			if now.After(en.Expires) {
+				delete(e.data, key)
+			}
+		}
+		e.sweepAt = max(1024, 2*len(e.data))
+	}
+}
+
+// MemoryStore is a [Store] keeping the data in memory for a limited time.
+// The data is lost when the program exits, so the buttons sent before can't be decoded after a restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries entries
+}
+
+// NewMemoryStore creates a new empty [MemoryStore] keeping the data for ttl after it was last stored.
+// The buttons with the data that was removed can't be decoded and fail with [ErrNotFound].
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{entries: newEntries(ttl)}
+}
+
+func (m *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.entries.get(key, time.Now())
+	return d, ok, nil
+}
+
+func (m *MemoryStore) Set(_ context.Context, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.entries.stale(key, now) {
+		m.entries.put(entry{Key: key, Data: data, Expires: now.Add(m.entries.ttl)}, now)
+	}
+	return nil
+}
+
+// FileStore is a [Store] keeping the data in memory for a limited time,
+// and appending the new data to a file, one JSON object per line.
+// The file is compacted when the store is created and when it grows twice as large as the data it keeps.
+type FileStore struct {
+	path string
+
+	mu      sync.RWMutex
+	entries entries
+	file    *os.File
+	// number of lines in the file
+	lines int
+}
+
+// NewFileStore creates a new [FileStore] appending the data to the file at path
+// and keeping it for ttl after it was last stored.
+// It loads the data saved there before, if the file exists.
+// The store must be closed with [FileStore.Close].
+func NewFileStore(path string, ttl time.Duration) (*FileStore, error) {
+	f := &FileStore{path: path, entries: newEntries(ttl)}
+	file, err := os.Open(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		defer file.Close()
+		now := time.Now()
+		sc := bufio.NewScanner(file)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			var en entry
+			// the last line is skipped if it was left half-written
+			if json.Unmarshal(sc.Bytes(), &en) != nil || now.After(en.Expires) {
+				continue
+			}
+			f.entries.data[en.Key] = en
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.compact(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	d, ok := f.entries.get(key, time.Now())
+	return d, ok, nil
+}
+
+func (f *FileStore) Set(_ context.Context, key string, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	if !f.entries.stale(key, now) {
+		return nil
+	}
+	en := entry{Key: key, Data: data, Expires: now.Add(f.entries.ttl)}
+	b, err := json.Marshal(en)
+	if err != nil {
+		return err
+	}
+	if _, err := f.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	f.entries.put(en, now)
+	f.lines++
+
+	if f.lines > 2*len(f.entries.data)+1024 {
+		return f.compact()
+	}
+	return nil
+}
+
+// Close closes the file. The store can't be used after that.
+func (f *FileStore) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// compact replaces the file with the data that hasn't expired and reopens it for appending.
+func (f *FileStore) compact() error {
+	now := time.Now()
+	var buf bytes.Buffer
+	for key, en := range f.entries.data {
+		if now.After(en.Expires) {
+			delete(f.entries.data, key)
+			continue
+		}
+		b, err := json.Marshal(en)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	if err := atomicfile.Write(f.path, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if f.file != nil {
+		f.file.Close()
+	}
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	f.file = file
+	f.lines = len(f.entries.data)
+	return nil
+}
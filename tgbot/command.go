@@ -0,0 +1,175 @@
+package tgbot
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+)
+
+// Command is a bot command parsed from the beginning of a message text or caption,
+// for example "/start@my_bot payload".
+type Command struct {
+	// Name of the command without the leading slash and the bot username, for example "start"
+	Name string
+	// Username of the bot the command was addressed to, without "@". Empty if not specified
+	Mention string
+	// Text following the command, with leading and trailing spaces removed.
+	// For the /start command sent with a deep link, it's the deep link payload.
+	Args string
+	// The message containing the command
+	Message objects.Message
+}
+
+// Fields returns the arguments of the command split around spaces.
+func (c Command) Fields() []string {
+	return strings.Fields(c.Args)
+}
+
+// ParseCommand parses the bot command the message starts with.
+// Offsets of the message entities are measured in UTF-16 code units, as described in [objects.MessageEntity].
+// It reports false if the message doesn't start with a bot command.
+func ParseCommand(msg objects.Message) (Command, bool) {
+	text, entities := msg.Text, msg.Entities
+	if text == nil {
+		text, entities = msg.Caption, msg.CaptionEntities
+	}
+	if text == nil || entities == nil {
+		return Command{}, false
+	}
+
+	for _, en := range *entities {
+		if en.Type != "bot_command" || en.Offset != 0 {
+			continue
+		}
+		end, ok := format.Index(*text, en.Length)
+		if !ok {
+			return Command{}, false
+		}
+		name := strings.TrimPrefix((*text)[:end], "/")
+		name, mention, _ := strings.Cut(name, "@")
+		return Command{
+			Name:    name,
+			Mention: mention,
+			Args:    strings.TrimSpace((*text)[end:]),
+			Message: msg,
+		}, true
+	}
+	return Command{}, false
+}
+
+type command struct {
+	name        string
+	description string
+	scopes      []objects.BotCommandScope
+}
+
+// SetUsername sets the username of the bot, without "@".
+// Commands addressed to other bots, for example "/start@other_bot", are ignored by the command handlers.
+// If it's not set, commands are handled regardless of the bot they were addressed to.
+func (r *Router) SetUsername(username string) {
+	r.root().username = strings.TrimPrefix(username, "@")
+}
+
+// OnCommand registers a handler for the messages starting with the bot command name, without the leading slash.
+// Command names are case-insensitive.
+//
+// If description is not empty, the command is published by [Router.PublishCommands]
+// for the given scopes, or for [objects.BotCommandScopeDefault] if there are no scopes.
+//
+// Commands are matched in the order of registration, along with other handlers,
+// so they should be registered before the handler for all messages with [Router.OnMessage].
+func (r *Router) OnCommand(name, description string, h func(Command) error, scopes ...objects.BotCommandScope) {
+	r.OnCommandContext(name, description, func(_ *Context, cmd Command) error {
+		return h(cmd)
+	}, scopes...)
+}
+
+// OnCommandContext is the same as [Router.OnCommand] for a handler receiving the [Context] of the update.
+func (r *Router) OnCommandContext(name, description string, h func(*Context, Command) error, scopes ...objects.BotCommandScope) {
+	name = strings.TrimPrefix(name, "/")
+	root := r.root()
+	root.commands = append(root.commands, command{
+		name:        name,
+		description: description,
+		scopes:      scopes,
+	})
+
+	parse := func(upd objects.Update) (Command, bool) {
+		if upd.Message == nil {
+			return Command{}, false
+		}
+		cmd, ok := ParseCommand(*upd.Message)
+		if !ok || !strings.EqualFold(cmd.Name, name) {
+			return Command{}, false
+		}
+		if cmd.Mention != "" && root.username != "" && !strings.EqualFold(cmd.Mention, root.username) {
+			return Command{}, false
+		}
+		return cmd, true
+	}
+	r.HandleContext(
+		func(upd objects.Update) bool {
+			_, ok := parse(upd)
+			return ok
+		},
+		func(c *Context) error {
+			cmd, _ := parse(c.Update)
+			return h(c, cmd)
+		},
+	)
+}
+
+// OnStart registers a handler for the /start command.
+// The deep link payload, if any, is available as [Command.Args].
+func (r *Router) OnStart(description string, h func(Command) error, scopes ...objects.BotCommandScope) {
+	r.OnCommand("start", description, h, scopes...)
+}
+
+// PublishCommands sets the list of the bot's commands to the commands registered with a description,
+// sending [methods.SetMyCommands] for every scope they were registered for.
+func (r *Router) PublishCommands(ctx context.Context, c *gotely.Client) error {
+	type scoped struct {
+		scope    objects.BotCommandScope
+		commands []objects.BotCommand
+	}
+	var order []string
+	byScope := map[string]*scoped{}
+
+	for _, cmd := range r.root().commands {
+		if cmd.description == "" {
+			continue
+		}
+		scopes := cmd.scopes
+		if len(scopes) == 0 {
+			scopes = []objects.BotCommandScope{objects.BotCommandScopeDefault{Type: "default"}}
+		}
+		for _, scope := range scopes {
+			b, err := json.Marshal(scope)
+			if err != nil {
+				return err
+			}
+			key := string(b)
+			if _, ok := byScope[key]; !ok {
+				byScope[key] = &scoped{scope: scope}
+				order = append(order, key)
+			}
+			byScope[key].commands = append(byScope[key].commands, objects.BotCommand{
+				Command:     strings.ToLower(cmd.name),
+				Description: cmd.description,
+			})
+		}
+	}
+
+	for _, key := range order {
+		s := byScope[key]
+		if _, err := gotely.Call(ctx, c, methods.SetMyCommands{Commands: s.commands, Scope: s.scope}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
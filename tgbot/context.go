@@ -0,0 +1,134 @@
+package tgbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+)
+
+// Context is passed to the handlers of an update.
+// It carries the update, the client for sending requests to the Telegram Bot API
+// and a logger, and it is canceled when the bot is stopped.
+//
+// Context implements [context.Context], so it can be passed to the requests sent from the handler:
+//
+//	r.HandleContext(tgbot.IsCommand, func(c *tgbot.Context) error {
+//		me, err := gotely.Call(c, c.Client, methods.GetMe{})
+//		if err != nil {
+//			return err
+//		}
+//		_, err = c.Reply("I am " + me.FirstName)
+//		return err
+//	})
+type Context struct {
+	context.Context
+
+	Update objects.Update
+	// nil if the update is handled with [Router.OnUpdate]
+	Client *gotely.Client
+	Logger *slog.Logger
+}
+
+// NewContext creates a new [Context] for upd.
+// If logger is nil, [slog.Default] is used.
+func NewContext(ctx context.Context, upd objects.Update, client *gotely.Client, logger *slog.Logger) *Context {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Context{
+		Context: ctx,
+		Update:  upd,
+		Client:  client,
+		Logger:  logger.With("update_id", upd.UpdateId),
+	}
+}
+
+// ContextHandler handles an incoming update with its [Context].
+type ContextHandler func(*Context) error
+
+// ContextBot is implemented by bots handling updates with a [Context], like [Router].
+// [longpolling.LongPollingBot] and [webhook.WebhookBot] call OnUpdateContext instead of OnUpdate
+// if their bot implements it.
+type ContextBot interface {
+	OnUpdateContext(*Context) error
+}
+
+// ErrNoClient is returned by the helpers of [Context] if it has no client.
+var ErrNoClient = errors.New("context has no client")
+
+// Reply sends a text message to the chat the update came from,
+// as a reply to the update's message, if there is one.
+// The message is sent to the same topic and on behalf of the same business connection.
+// opts are applied to the request before it is sent.
+func (c *Context) Reply(text string, opts ...func(*methods.SendMessage)) (objects.Message, error) {
+	if c.Client == nil {
+		return objects.Message{}, ErrNoClient
+	}
+	chat := ChatOf(c.Update)
+	if chat == nil {
+		return objects.Message{}, fmt.Errorf("can't reply to an update without a chat")
+	}
+	m := methods.SendMessage{
+		ChatId: fmt.Sprint(chat.Id),
+		Text:   text,
+	}
+	if msg := MessageOf(c.Update); msg != nil {
+		m.BusinessConnectionId = msg.BusinessConnectionId
+		m.MessageThreadId = msg.MessageThreadId
+		// a callback query is answered next to the message, not as a reply to the bot's own message
+		if c.Update.CallbackQuery == nil {
+			m.ReplyParameters = &objects.ReplyParameters{MessageId: msg.MessageId}
+		}
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return gotely.Call(c, c.Client, m)
+}
+
+// Answer answers the update's callback query, showing text as a notification to the user.
+// text can be empty.
+func (c *Context) Answer(text string) error {
+	if c.Client == nil {
+		return ErrNoClient
+	}
+	q := c.Update.CallbackQuery
+	if q == nil {
+		return fmt.Errorf("can't answer an update without a callback query")
+	}
+	m := methods.AnswerCallbackQuery{CallbackQueryId: q.Id}
+	if text != "" {
+		m.Text = &text
+	}
+	_, err := gotely.Call(c, c.Client, m)
+	return err
+}
+
+// Edit replaces the text of the message the update's callback query was sent from.
+// For other updates, it edits the update's message, which must be sent by the bot.
+// opts are applied to the request before it is sent.
+func (c *Context) Edit(text string, opts ...func(*methods.EditMessageText)) (objects.MessageOrTrue, error) {
+	if c.Client == nil {
+		return objects.MessageOrTrue{}, ErrNoClient
+	}
+	m := methods.EditMessageText{Text: text}
+	if q := c.Update.CallbackQuery; q != nil && q.InlineMessageId != nil {
+		m.InlineMessageId = q.InlineMessageId
+	} else if msg := MessageOf(c.Update); msg != nil {
+		chatId := fmt.Sprint(msg.Chat.Id)
+		m.ChatId = &chatId
+		m.MessageId = &msg.MessageId
+		m.BusinessConnectionId = msg.BusinessConnectionId
+	} else {
+		return objects.MessageOrTrue{}, fmt.Errorf("can't edit an update without a message")
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return gotely.Call(c, c.Client, m)
+}
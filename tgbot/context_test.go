@@ -0,0 +1,62 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+func TestContext(t *testing.T) {
+	var sent []gotely.Method
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			sent = append(sent, body)
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`{"message_id":2,"date":0,"chat":{"id":1,"type":"private"}}`)}, nil
+		}
+	}))
+
+	thread := 7
+	text := "/ping"
+	r := tgbot.NewRouter()
+	r.OnCommandContext("ping", "", func(c *tgbot.Context, cmd tgbot.Command) error {
+		_, err := c.Reply("pong")
+		return err
+	})
+	upd := objects.Update{Message: &objects.Message{
+		MessageId:       1,
+		MessageThreadId: &thread,
+		Chat:            objects.Chat{Id: 1, Type: "supergroup"},
+		Text:            &text,
+		Entities:        &[]objects.MessageEntity{{Type: "bot_command", Offset: 0, Length: 5}},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := r.OnUpdateContext(tgbot.NewContext(ctx, upd, client, nil)); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(sent))
+	}
+	m, ok := sent[0].(methods.SendMessage)
+	if !ok || m.ChatId != "1" || m.Text != "pong" || *m.MessageThreadId != 7 || m.ReplyParameters.MessageId != 1 {
+		t.Fatalf("unexpected request: %#v", sent[0])
+	}
+
+	// the requests are sent with the context of the update
+	cancel()
+	if err := r.OnUpdateContext(tgbot.NewContext(ctx, upd, client, nil)); err == nil {
+		t.Fatal("expected an error after the context was canceled")
+	}
+
+	if err := r.OnUpdate(upd); err != tgbot.ErrNoClient {
+		t.Fatalf("expected ErrNoClient, got %v", err)
+	}
+}
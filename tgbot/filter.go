@@ -0,0 +1,231 @@
+package tgbot
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/objects"
+)
+
+// Filter reports whether an update should be handled.
+// Filters can be combined with [And], [Or] and [Not],
+// and are used with [Router.With] and [Router.Handle].
+type Filter func(objects.Update) bool
+
+// And returns a [Filter] matching the updates that match every one of filters.
+func And(filters ...Filter) Filter {
+	return func(upd objects.Update) bool {
+		for _, f := range filters {
+			if !f(upd) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a [Filter] matching the updates that match at least one of filters.
+func Or(filters ...Filter) Filter {
+	return func(upd objects.Update) bool {
+		for _, f := range filters {
+			if f(upd) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a [Filter] matching the updates that don't match f.
+func Not(f Filter) Filter {
+	return func(upd objects.Update) bool {
+		return !f(upd)
+	}
+}
+
+// MessageFilter returns a [Filter] matching the updates with a message, as returned by [MessageOf],
+// for which f returns true.
+func MessageFilter(f func(objects.Message) bool) Filter {
+	return func(upd objects.Update) bool {
+		msg := MessageOf(upd)
+		return msg != nil && f(*msg)
+	}
+}
+
+// ChatFilter returns a [Filter] matching the updates from a chat, as returned by [ChatOf],
+// for which f returns true.
+func ChatFilter(f func(objects.Chat) bool) Filter {
+	return func(upd objects.Update) bool {
+		chat := ChatOf(upd)
+		return chat != nil && f(*chat)
+	}
+}
+
+// CallbackQueryFilter returns a [Filter] matching the updates with a callback query
+// for which f returns true.
+func CallbackQueryFilter(f func(objects.CallbackQuery) bool) Filter {
+	return func(upd objects.Update) bool {
+		return upd.CallbackQuery != nil && f(*upd.CallbackQuery)
+	}
+}
+
+// ChatType returns a [Filter] matching the updates from chats of the given types:
+// “private”, “group”, “supergroup” or “channel”.
+func ChatType(types ...string) Filter {
+	return ChatFilter(func(c objects.Chat) bool {
+		return slices.Contains(types, c.Type)
+	})
+}
+
+var (
+	// PrivateChat matches the updates from private chats.
+	PrivateChat = ChatType("private")
+	// GroupChat matches the updates from groups and supergroups.
+	GroupChat = ChatType("group", "supergroup")
+	// Supergroup matches the updates from supergroups.
+	Supergroup = ChatType("supergroup")
+	// Channel matches the updates from channels.
+	Channel = ChatType("channel")
+)
+
+// ChatId returns a [Filter] matching the updates from the chats with the given identifiers.
+func ChatId(ids ...int64) Filter {
+	return ChatFilter(func(c objects.Chat) bool {
+		return slices.Contains(ids, c.Id)
+	})
+}
+
+// FromUser returns a [Filter] matching the updates caused by the users with the given identifiers.
+func FromUser(ids ...int64) Filter {
+	return func(upd objects.Update) bool {
+		u := SenderOf(upd)
+		return u != nil && slices.Contains(ids, u.Id)
+	}
+}
+
+// FromAdmin returns a [Filter] matching the updates caused by an administrator or the creator of the chat.
+// The administrators of every chat are requested with [methods.GetChatAdministrators] using c,
+// at most once every 5 minutes, and each request is canceled after 10 seconds.
+// Updates are not matched if the request fails.
+// For more control, use [Admins].
+func FromAdmin(c *gotely.Client) Filter {
+	return NewAdmins(c, 5*time.Minute).Filter(10 * time.Second)
+}
+
+// text returns the text or the caption of the message.
+func text(msg objects.Message) (string, bool) {
+	if msg.Text != nil {
+		return *msg.Text, true
+	}
+	if msg.Caption != nil {
+		return *msg.Caption, true
+	}
+	return "", false
+}
+
+// Text matches the messages with a text or a caption.
+var Text = MessageFilter(func(m objects.Message) bool {
+	_, ok := text(m)
+	return ok
+})
+
+// TextEquals returns a [Filter] matching the messages which text or caption equals s.
+func TextEquals(s string) Filter {
+	return MessageFilter(func(m objects.Message) bool {
+		t, ok := text(m)
+		return ok && t == s
+	})
+}
+
+// TextHasPrefix returns a [Filter] matching the messages which text or caption starts with prefix.
+func TextHasPrefix(prefix string) Filter {
+	return MessageFilter(func(m objects.Message) bool {
+		t, ok := text(m)
+		return ok && strings.HasPrefix(t, prefix)
+	})
+}
+
+// TextMatches returns a [Filter] matching the messages which text or caption matches re.
+func TextMatches(re *regexp.Regexp) Filter {
+	return MessageFilter(func(m objects.Message) bool {
+		t, ok := text(m)
+		return ok && re.MatchString(t)
+	})
+}
+
+var (
+	// IsCommand matches the messages starting with a bot command.
+	IsCommand = MessageFilter(func(m objects.Message) bool {
+		_, ok := ParseCommand(m)
+		return ok
+	})
+	// IsReply matches the messages replying to another message.
+	IsReply = MessageFilter(func(m objects.Message) bool {
+		return m.ReplyToMessage != nil
+	})
+	// IsForwarded matches the forwarded messages.
+	IsForwarded = MessageFilter(func(m objects.Message) bool {
+		return m.ForwardOrigin != nil
+	})
+	// HasPhoto matches the messages with a photo.
+	HasPhoto = MessageFilter(func(m objects.Message) bool {
+		return m.Photo != nil
+	})
+	// HasVideo matches the messages with a video.
+	HasVideo = MessageFilter(func(m objects.Message) bool {
+		return m.Video != nil
+	})
+	// HasAnimation matches the messages with an animation.
+	HasAnimation = MessageFilter(func(m objects.Message) bool {
+		return m.Animation != nil
+	})
+	// HasAudio matches the messages with an audio file.
+	HasAudio = MessageFilter(func(m objects.Message) bool {
+		return m.Audio != nil
+	})
+	// HasVoice matches the messages with a voice note.
+	HasVoice = MessageFilter(func(m objects.Message) bool {
+		return m.Voice != nil
+	})
+	// HasVideoNote matches the messages with a video note.
+	HasVideoNote = MessageFilter(func(m objects.Message) bool {
+		return m.VideoNote != nil
+	})
+	// HasDocument matches the messages with a general file.
+	HasDocument = MessageFilter(func(m objects.Message) bool {
+		return m.Document != nil
+	})
+	// HasSticker matches the messages with a sticker.
+	HasSticker = MessageFilter(func(m objects.Message) bool {
+		return m.Sticker != nil
+	})
+	// HasLocation matches the messages with a location.
+	HasLocation = MessageFilter(func(m objects.Message) bool {
+		return m.Location != nil
+	})
+	// HasContact matches the messages with a contact.
+	HasContact = MessageFilter(func(m objects.Message) bool {
+		return m.Contact != nil
+	})
+	// HasPoll matches the messages with a poll.
+	HasPoll = MessageFilter(func(m objects.Message) bool {
+		return m.Poll != nil
+	})
+)
+
+// CallbackDataEquals returns a [Filter] matching the callback queries which data equals s.
+func CallbackDataEquals(s string) Filter {
+	return CallbackQueryFilter(func(cq objects.CallbackQuery) bool {
+		return cq.Data != nil && *cq.Data == s
+	})
+}
+
+// CallbackDataHasPrefix returns a [Filter] matching the callback queries which data starts with prefix.
+func CallbackDataHasPrefix(prefix string) Filter {
+	return CallbackQueryFilter(func(cq objects.CallbackQuery) bool {
+		return cq.Data != nil && strings.HasPrefix(*cq.Data, prefix)
+	})
+}
@@ -0,0 +1,7 @@
+// This package provides a finite-state machine for multi-step conversations with the bot,
+// such as onboarding, forms or checkout.
+// Every conversation is identified by a [Key] built from the update, has a named state with attached data,
+// and is persisted in a [Storage], so it survives restarts of the bot.
+//
+// Licensed under the MIT License. See LICENSE file for details.
+package fsm
@@ -0,0 +1,356 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+// Key identifies a conversation.
+type Key struct {
+	ChatId   int64
+	UserId   int64
+	ThreadId int
+	// Unique identifier of the business connection the conversation goes through, if any
+	BusinessConnectionId string
+}
+
+// String returns the representation of the key used with [Storage].
+func (k Key) String() string {
+	return fmt.Sprintf("%d:%d:%d:%s", k.ChatId, k.UserId, k.ThreadId, k.BusinessConnectionId)
+}
+
+// KeyFunc builds the [Key] of the conversation an update belongs to.
+// It reports false if the update can't be a part of a conversation.
+type KeyFunc func(objects.Update) (Key, bool)
+
+// ByChatAndUser identifies conversations by the chat and the user.
+// It's the default [KeyFunc].
+func ByChatAndUser(upd objects.Update) (Key, bool) {
+	chat, user := tgbot.ChatOf(upd), tgbot.SenderOf(upd)
+	if chat == nil || user == nil {
+		return Key{}, false
+	}
+	return Key{ChatId: chat.Id, UserId: user.Id}, true
+}
+
+// ByThread identifies conversations by the chat, the user and the forum topic,
+// so the user can have a separate conversation in every topic.
+func ByThread(upd objects.Update) (Key, bool) {
+	k, ok := ByChatAndUser(upd)
+	if !ok {
+		return k, false
+	}
+	if msg := tgbot.MessageOf(upd); msg != nil && msg.MessageThreadId != nil {
+		k.ThreadId = *msg.MessageThreadId
+	}
+	return k, true
+}
+
+// ByBusinessConnection identifies conversations by the chat, the user and the business connection,
+// so the conversations going through different business accounts are separated.
+func ByBusinessConnection(upd objects.Update) (Key, bool) {
+	k, ok := ByChatAndUser(upd)
+	if !ok {
+		return k, false
+	}
+	if msg := tgbot.MessageOf(upd); msg != nil && msg.BusinessConnectionId != nil {
+		k.BusinessConnectionId = *msg.BusinessConnectionId
+	}
+	return k, true
+}
+
+// Conversation is passed to the handlers of the states.
+// Changes made to it are saved after the handler returns without an error.
+type Conversation struct {
+	Key    Key
+	Update objects.Update
+	// Name of the current state
+	State string
+	// Data collected during the conversation
+	Data map[string]string
+
+	next     string
+	finished bool
+}
+
+// Next moves the conversation to the state with the given name.
+func (c *Conversation) Next(state string) {
+	c.next = state
+	c.finished = false
+}
+
+// Finish ends the conversation and removes its state from the storage.
+func (c *Conversation) Finish() {
+	c.finished = true
+}
+
+// Handler handles an update that is a part of a conversation.
+type Handler func(c *Conversation) error
+
+// Machine is a finite-state machine handling conversations.
+//
+// Example:
+//
+//	m := fsm.New(fsm.NewMemoryStorage(), fsm.WithTimeout(10*time.Minute), fsm.WithCancelCommands("cancel"))
+//	m.State("name", func(c *fsm.Conversation) error {
+//		c.Data["name"] = *c.Update.Message.Text
+//		c.Next("age")
+//		return nil
+//	})
+//	m.State("age", func(c *fsm.Conversation) error {
+//		// saving the form
+//		c.Finish()
+//		return nil
+//	})
+//
+//	r := tgbot.NewRouter()
+//	m.Attach(r)
+//	r.OnCommand("form", "fill the form", func(cmd tgbot.Command) error {
+//		return m.Start(objects.Update{Message: &cmd.Message}, "name")
+//	})
+type Machine struct {
+	storage  Storage
+	key      KeyFunc
+	states   map[string]Handler
+	timeout  time.Duration
+	cancel   []string
+	onCancel Handler
+	onExpire Handler
+
+	mu     sync.Mutex
+	pruned time.Time
+}
+
+// New creates a new [Machine] persisting conversations in storage.
+func New(storage Storage, opts ...Option) *Machine {
+	m := &Machine{
+		storage: storage,
+		key:     ByChatAndUser,
+		states:  make(map[string]Handler),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+type Option func(*Machine)
+
+// WithKey sets the function identifying conversations.
+// Defaults to [ByChatAndUser].
+func WithKey(f KeyFunc) Option {
+	return func(m *Machine) {
+		m.key = f
+	}
+}
+
+// WithTimeout sets the time after the last transition when the conversation expires.
+// Defaults to 0, meaning that conversations never expire.
+// If the storage implements [Pruner], the expired conversations are removed from it
+// within another timeout, without calling the handler set with [OnExpire].
+func WithTimeout(t time.Duration) Option {
+	return func(m *Machine) {
+		m.timeout = t
+	}
+}
+
+// WithCancelCommands sets the bot commands, without the leading slash, that cancel an active conversation.
+func WithCancelCommands(commands ...string) Option {
+	return func(m *Machine) {
+		m.cancel = commands
+	}
+}
+
+// OnCancel sets the handler called when the conversation is canceled with one of the cancel commands.
+// The conversation is finished regardless of the calls made by the handler.
+func OnCancel(h Handler) Option {
+	return func(m *Machine) {
+		m.onCancel = h
+	}
+}
+
+// OnExpire sets the handler called with the first update of an expired conversation.
+// The conversation is finished regardless of the calls made by the handler,
+// and the update is then handled as if there was no conversation.
+func OnExpire(h Handler) Option {
+	return func(m *Machine) {
+		m.onExpire = h
+	}
+}
+
+// State registers the handler of the state with the given name.
+func (m *Machine) State(name string, h Handler) {
+	m.states[name] = h
+}
+
+// Start begins the conversation the update belongs to in the given state,
+// replacing the active one, if any.
+// The next update of the conversation is handled by the handler of the state.
+func (m *Machine) Start(upd objects.Update, state string) error {
+	return m.StartContext(context.Background(), upd, state)
+}
+
+// StartContext is the same as [Machine.Start], passing ctx to the storage.
+func (m *Machine) StartContext(ctx context.Context, upd objects.Update, state string) error {
+	k, ok := m.key(upd)
+	if !ok {
+		return fmt.Errorf("the update can't be a part of a conversation")
+	}
+	if _, ok := m.states[state]; !ok {
+		return fmt.Errorf("unknown state: %s", state)
+	}
+	return m.save(ctx, k.String(), State{
+		Name:      state,
+		Data:      map[string]string{},
+		UpdatedAt: time.Now(),
+	})
+}
+
+// Active reports whether the update belongs to an active conversation which hasn't expired.
+// It can be used as a [tgbot.Filter]. It doesn't modify the storage,
+// and reports false if the state can't be loaded.
+func (m *Machine) Active(upd objects.Update) bool {
+	k, ok := m.key(upd)
+	if !ok {
+		return false
+	}
+	s, ok, err := m.storage.Get(context.Background(), k.String())
+	return err == nil && ok && !m.expired(s)
+}
+
+// Attach registers the machine in the router as [Machine.Middleware],
+// so the updates belonging to active conversations are handled by the handlers of their states
+// before any handler registered in the router.
+func (m *Machine) Attach(r *tgbot.Router) {
+	r.Use(m.Middleware)
+}
+
+// Middleware passes the updates belonging to active conversations to the handlers of their states,
+// and the rest of the updates to next.
+func (m *Machine) Middleware(next tgbot.ContextHandler) tgbot.ContextHandler {
+	return func(c *tgbot.Context) error {
+		handled, err := m.handle(c, c.Update)
+		if err != nil || handled {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// Handle passes the update to the handler of the current state of its conversation
+// and saves the changes made by the handler.
+// Updates not belonging to an active conversation are ignored.
+func (m *Machine) Handle(upd objects.Update) error {
+	_, err := m.handle(context.Background(), upd)
+	return err
+}
+
+// HandleContext is the same as [Machine.Handle], passing the context of the update to the storage.
+func (m *Machine) HandleContext(c *tgbot.Context) error {
+	_, err := m.handle(c, c.Update)
+	return err
+}
+
+// handle reports whether the update belonged to an active conversation.
+// Expired conversations are finished by it, reporting false.
+func (m *Machine) handle(ctx context.Context, upd objects.Update) (bool, error) {
+	k, ok := m.key(upd)
+	if !ok {
+		return false, nil
+	}
+	s, ok, err := m.storage.Get(ctx, k.String())
+	if err != nil || !ok {
+		return false, err
+	}
+	c := &Conversation{
+		Key:    k,
+		Update: upd,
+		State:  s.Name,
+		Data:   s.Data,
+		next:   s.Name,
+	}
+	if c.Data == nil {
+		c.Data = map[string]string{}
+	}
+
+	if m.expired(s) {
+		if m.onExpire != nil {
+			if err := m.onExpire(c); err != nil {
+				return false, err
+			}
+		}
+		return false, m.storage.Delete(ctx, k.String())
+	}
+
+	if m.isCancel(upd) {
+		if m.onCancel != nil {
+			if err := m.onCancel(c); err != nil {
+				return true, err
+			}
+		}
+		return true, m.storage.Delete(ctx, k.String())
+	}
+
+	h, ok := m.states[s.Name]
+	if !ok {
+		// the state was removed from the code, but remained in the storage
+		return false, m.storage.Delete(ctx, k.String())
+	}
+	if err := h(c); err != nil {
+		return true, err
+	}
+
+	if c.finished {
+		return true, m.storage.Delete(ctx, k.String())
+	}
+	return true, m.save(ctx, k.String(), State{
+		Name:      c.next,
+		Data:      c.Data,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// save stores the state, then removes the expired conversations from the storage,
+// if it implements [Pruner] and they weren't removed during the last timeout.
+func (m *Machine) save(ctx context.Context, key string, s State) error {
+	if err := m.storage.Set(ctx, key, s); err != nil {
+		return err
+	}
+	p, ok := m.storage.(Pruner)
+	if !ok || m.timeout <= 0 {
+		return nil
+	}
+	now := time.Now()
+	m.mu.Lock()
+	if now.Sub(m.pruned) < m.timeout {
+		m.mu.Unlock()
+		return nil
+	}
+	m.pruned = now
+	m.mu.Unlock()
+	return p.Prune(ctx, now.Add(-m.timeout))
+}
+
+func (m *Machine) expired(s State) bool {
+	return m.timeout > 0 && time.Since(s.UpdatedAt) > m.timeout
+}
+
+func (m *Machine) isCancel(upd objects.Update) bool {
+	if upd.Message == nil || len(m.cancel) == 0 {
+		return false
+	}
+	cmd, ok := tgbot.ParseCommand(*upd.Message)
+	if !ok {
+		return false
+	}
+	return slices.ContainsFunc(m.cancel, func(c string) bool {
+		return strings.EqualFold(strings.TrimPrefix(c, "/"), cmd.Name)
+	})
+}
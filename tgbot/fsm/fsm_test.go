@@ -0,0 +1,204 @@
+package fsm_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+	"github.com/bigelle/gotely/tgbot/fsm"
+)
+
+func message(text string) objects.Update {
+	return objects.Update{Message: &objects.Message{
+		Chat: objects.Chat{Id: 1, Type: "private"},
+		From: &objects.User{Id: 1},
+		Text: &text,
+	}}
+}
+
+func newMachine(t *testing.T, path string, done *map[string]string) (*tgbot.Router, *fsm.Machine) {
+	storage, err := fsm.NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	m := fsm.New(storage)
+	m.State("name", func(c *fsm.Conversation) error {
+		c.Data["name"] = *c.Update.Message.Text
+		c.Next("age")
+		return nil
+	})
+	m.State("age", func(c *fsm.Conversation) error {
+		c.Data["age"] = *c.Update.Message.Text
+		*done = c.Data
+		c.Finish()
+		return nil
+	})
+
+	r := tgbot.NewRouter()
+	m.Attach(r)
+	r.OnMessage(func(msg objects.Message) error {
+		t.Fatalf("unexpected message outside of the conversation: %s", *msg.Text)
+		return nil
+	})
+	return r, m
+}
+
+func TestConversationSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.json")
+	var done map[string]string
+
+	r, m := newMachine(t, path, &done)
+	if err := m.Start(message("/form"), "name"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := r.OnUpdate(message("John")); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the conversation continues with the states loaded from the file
+	r, m = newMachine(t, path, &done)
+	if !m.Active(message("42")) {
+		t.Fatal("expected the conversation to be active after restart")
+	}
+	if err := r.OnUpdate(message("42")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if done["name"] != "John" || done["age"] != "42" {
+		t.Fatalf("unexpected conversation data: %v", done)
+	}
+	if m.Active(message("hello")) {
+		t.Fatal("expected the conversation to be finished")
+	}
+}
+
+func command(name string) objects.Update {
+	upd := message("/" + name)
+	upd.Message.Entities = &[]objects.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(name) + 1}}
+	return upd
+}
+
+func TestConversationExpires(t *testing.T) {
+	ctx := context.Background()
+	storage := fsm.NewMemoryStorage()
+	var expired, outside bool
+	m := fsm.New(storage, fsm.WithTimeout(time.Minute), fsm.OnExpire(func(c *fsm.Conversation) error {
+		expired = c.State == "name"
+		return nil
+	}))
+	m.State("name", func(c *fsm.Conversation) error {
+		t.Fatal("unexpected call of the handler of an expired conversation")
+		return nil
+	})
+	r := tgbot.NewRouter()
+	m.Attach(r)
+	r.OnMessage(func(objects.Message) error {
+		outside = true
+		return nil
+	})
+
+	key := fsm.Key{ChatId: 1, UserId: 1}.String()
+	if err := storage.Set(ctx, key, fsm.State{Name: "name", UpdatedAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if m.Active(message("John")) {
+		t.Fatal("expected the conversation to be expired")
+	}
+	if err := r.OnUpdate(message("John")); err != nil {
+		t.Fatal(err)
+	}
+	if !expired || !outside {
+		t.Fatalf("expected the expire handler and the handler outside of the conversation to be called, got %v and %v", expired, outside)
+	}
+	if _, ok, _ := storage.Get(ctx, key); ok {
+		t.Fatal("expected the expired conversation to be removed")
+	}
+}
+
+func TestConversationCancel(t *testing.T) {
+	var canceled bool
+	m := fsm.New(fsm.NewMemoryStorage(), fsm.WithCancelCommands("/cancel"), fsm.OnCancel(func(*fsm.Conversation) error {
+		canceled = true
+		return nil
+	}))
+	m.State("name", func(c *fsm.Conversation) error {
+		t.Fatal("unexpected call of the handler of a canceled conversation")
+		return nil
+	})
+	r := tgbot.NewRouter()
+	m.Attach(r)
+
+	if err := m.Start(message("/form"), "name"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.OnUpdate(command("cancel")); err != nil {
+		t.Fatal(err)
+	}
+	if !canceled || m.Active(message("John")) {
+		t.Fatal("expected the conversation to be canceled")
+	}
+}
+
+func TestFailedHandlerKeepsState(t *testing.T) {
+	ctx := context.Background()
+	storage := fsm.NewMemoryStorage()
+	m := fsm.New(storage)
+	m.State("name", func(c *fsm.Conversation) error {
+		c.Data["name"] = "dirty"
+		c.Next("age")
+		return errors.New("failed")
+	})
+	m.State("age", func(*fsm.Conversation) error { return nil })
+	r := tgbot.NewRouter()
+	m.Attach(r)
+
+	if err := m.Start(message("/form"), "name"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.OnUpdate(message("John")); err == nil {
+		t.Fatal("expected the error of the handler")
+	}
+	s, ok, err := storage.Get(ctx, fsm.Key{ChatId: 1, UserId: 1}.String())
+	if err != nil || !ok {
+		t.Fatalf("expected the conversation to remain, got %v", err)
+	}
+	if s.Name != "name" || len(s.Data) != 0 {
+		t.Fatalf("expected the state to be unchanged, got %+v", s)
+	}
+}
+
+func TestExpiredConversationsArePruned(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "states.json")
+	storage, err := fsm.NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	m := fsm.New(storage, fsm.WithTimeout(time.Minute))
+	m.State("name", func(c *fsm.Conversation) error {
+		return nil
+	})
+
+	// a conversation that is never continued
+	stale := fsm.Key{ChatId: 2, UserId: 2}.String()
+	if err := storage.Set(ctx, stale, fsm.State{Name: "name", UpdatedAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := m.Start(message("/form"), "name"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	storage, err = fsm.NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, ok, _ := storage.Get(ctx, stale); ok {
+		t.Fatal("expected the expired conversation to be removed from the file")
+	}
+	if _, ok, _ := storage.Get(ctx, fsm.Key{ChatId: 1, UserId: 1}.String()); !ok {
+		t.Fatal("expected the started conversation to be saved")
+	}
+}
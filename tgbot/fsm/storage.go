@@ -0,0 +1,175 @@
+package fsm
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/bigelle/gotely/internal/atomicfile"
+)
+
+// State is the state of a conversation.
+type State struct {
+	// Name of the current state
+	Name string `json:"name"`
+	// Data collected during the conversation
+	Data map[string]string `json:"data,omitempty"`
+	// Time of the last transition, used to expire inactive conversations
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Storage persists the states of conversations.
+// Implementations must be safe for concurrent use,
+// and must not share the Data maps of the states with the callers.
+type Storage interface {
+	// Get returns the state stored with key.
+	// It reports false if there is no such state.
+	Get(ctx context.Context, key string) (State, bool, error)
+	// Set stores the state with key, replacing the previous one.
+	Set(ctx context.Context, key string, s State) error
+	// Delete removes the state stored with key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// Pruner is implemented by the storages able to remove the states of expired conversations.
+// A [Machine] created with [WithTimeout] calls Prune when it saves a state, at most once per timeout,
+// so the conversations that are never continued don't stay in the storage forever.
+type Pruner interface {
+	// Prune removes the states updated before t.
+	Prune(ctx context.Context, t time.Time) error
+}
+
+// MemoryStorage is a [Storage] keeping states in memory.
+// States are lost when the program exits.
+type MemoryStorage struct {
+	mu     sync.RWMutex
+	states map[string]State
+}
+
+// NewMemoryStorage creates a new empty [MemoryStorage].
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{states: make(map[string]State)}
+}
+
+func (m *MemoryStorage) Get(_ context.Context, key string) (State, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.states[key]
+	s.Data = maps.Clone(s.Data)
+	return s, ok, nil
+}
+
+func (m *MemoryStorage) Set(_ context.Context, key string, s State) error {
+	s.Data = maps.Clone(s.Data)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[key] = s
+	return nil
+}
+
+func (m *MemoryStorage) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key)
+	return nil
+}
+
+func (m *MemoryStorage) Prune(_ context.Context, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, s := range m.states {
+		if s.UpdatedAt.Before(t) {
+			delete(m.states, key)
+		}
+	}
+	return nil
+}
+
+// FileStorage is a [Storage] keeping states in memory
+// and saving all of them to a JSON file on every change.
+// It's suitable for bots with a moderate number of active conversations.
+type FileStorage struct {
+	path string
+
+	mu     sync.RWMutex
+	states map[string]State
+}
+
+// NewFileStorage creates a new [FileStorage] saving states to the file at path,
+// and loads the states saved there before, if the file exists.
+func NewFileStorage(path string) (*FileStorage, error) {
+	f := &FileStorage{
+		path:   path,
+		states: make(map[string]State),
+	}
+	if err := atomicfile.ReadJSON(path, &f.states); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileStorage) Get(_ context.Context, key string) (State, bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	s, ok := f.states[key]
+	s.Data = maps.Clone(s.Data)
+	return s, ok, nil
+}
+
+func (f *FileStorage) Set(_ context.Context, key string, s State) error {
+	s.Data = maps.Clone(s.Data)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prev, existed := f.states[key]
+	f.states[key] = s
+	if err := f.save(); err != nil {
+		// keeping memory consistent with the file
+		if existed {
+			f.states[key] = prev
+		} else {
+			delete(f.states, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (f *FileStorage) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prev, existed := f.states[key]
+	if !existed {
+		return nil
+	}
+	delete(f.states, key)
+	if err := f.save(); err != nil {
+		f.states[key] = prev
+		return err
+	}
+	return nil
+}
+
+func (f *FileStorage) Prune(_ context.Context, t time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pruned := make(map[string]State)
+	for key, s := range f.states {
+		if s.UpdatedAt.Before(t) {
+			pruned[key] = s
+			delete(f.states, key)
+		}
+	}
+	if len(pruned) == 0 {
+		return nil
+	}
+	if err := f.save(); err != nil {
+		maps.Copy(f.states, pruned)
+		return err
+	}
+	return nil
+}
+
+func (f *FileStorage) save() error {
+	return atomicfile.WriteJSON(f.path, f.states)
+}
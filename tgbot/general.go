@@ -35,3 +35,13 @@ func (b DefaultBot) Client() *http.Client {
 func (b DefaultBot) ApiURLTemplate() string {
 	return gotely.DEFAULT_URL_TEMPLATE
 }
+
+// NewClient creates a [gotely.Client] configured with the token, HTTP client
+// and API URL template of b. Additional request options opts are applied on top of them.
+func NewClient(b Bot, opts ...gotely.RequestOption) *gotely.Client {
+	o := []gotely.RequestOption{
+		gotely.WithClient(b.Client()),
+		gotely.WithUrl(b.ApiURLTemplate()),
+	}
+	return gotely.NewClient(b.Token(), append(o, opts...)...)
+}
@@ -2,10 +2,15 @@ package longpolling
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"math"
+	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bigelle/gotely"
 	"github.com/bigelle/gotely/objects"
@@ -13,64 +18,190 @@ import (
 )
 
 // LongPollingBot receives [objects.Update] from the Telegram Bot API
-// using the long-polling method and responds using the OnUpdate function defined in [tgbot.Bot].
+// using the long-polling method and responds using the OnUpdate function defined in [tgbot.Bot],
+// or OnUpdateContext if the bot implements [tgbot.ContextBot].
 type LongPollingBot struct {
 	Bot tgbot.Bot
 
+	client *gotely.Client
+
 	// for getting updates
 	offset         *int
 	limit          int
 	timeout        int
 	allowedUpdates *[]string
+	offsets        OffsetStore
+	ack            bool
+	retries        int
+
+	// for recovering from errors
+	backoff        gotely.RetryPolicy
+	deleteWebhook  bool
+	webhookDeleted bool
+	err            error
 
 	// service
-	chUpdate    chan objects.Update
-	ctx         context.Context
-	cancel      context.CancelFunc
+	pool        *tgbot.WorkerPool
+	dropped     *atomic.Int64   // the lowest id of the updates dropped after Stop
+	ctx         context.Context // canceled on Stop
+	pollCtx     context.Context // canceled on Shutdown and Stop
+	state       *state
 	workingPool uint
+	ordered     bool
+	confirm     bool
 	logger      slog.Logger
 }
 
+// state is shared by the copies of a [LongPollingBot],
+// so it can be stopped from any of them.
+type state struct {
+	mu       sync.Mutex
+	running  bool
+	stop     context.CancelFunc
+	stopPoll context.CancelFunc
+	done     chan struct{}
+}
+
 // Start initializes the bot and begins polling for updates.
-// Each new update is passed to the OnUpdate function defined in [tgbot.Bot].
-func (l *LongPollingBot) Start() {
+// Each new update is passed to the OnUpdate function defined in [tgbot.Bot],
+// or to OnUpdateContext with a [tgbot.Context] canceled on Stop.
+// It's the same as [LongPollingBot.Run] with [context.Background].
+func (l *LongPollingBot) Start() error {
+	return l.Run(context.Background())
+}
+
+// ErrAlreadyRunning is returned by [LongPollingBot.Run] if the bot is already running.
+var ErrAlreadyRunning = errors.New("bot is already running")
+
+// Run validates the bot, then polls for updates and handles them until the bot is stopped
+// with [LongPollingBot.Stop] or [LongPollingBot.Shutdown], or ctx is canceled.
+// Canceling ctx stops the bot the same way as Stop.
+//
+// Requests for updates failing with network or server errors are repeated with a jittered exponential backoff,
+// or after the delay requested by the Telegram Bot API if there are too many requests.
+// Run returns nil after the bot was stopped, the error of ctx if it was canceled,
+// the error that prevented the bot from starting, or the error the bot can't recover from:
+// an invalid or revoked token, or a conflict with a webhook or another instance of the bot.
+// In the latter case, the received updates are still handled before Run returns.
+func (l *LongPollingBot) Run(ctx context.Context) error {
 	l.logger.Info("validating...")
 	if err := l.Validate(); err != nil {
-		l.logger.Error("bot failed validation;", "err", err.Error())
-		os.Exit(1)
+		return err
 	}
 
 	l.logger.Info("initializing...")
-	l.chUpdate = make(chan objects.Update, l.workingPool)
-	l.ctx, l.cancel = context.WithCancel(context.Background())
+	st := l.state
+	st.mu.Lock()
+	if st.running {
+		st.mu.Unlock()
+		return ErrAlreadyRunning
+	}
+	st.running = true
+	st.done = make(chan struct{})
+	l.ctx, st.stop = context.WithCancel(ctx)
+	l.pollCtx, st.stopPoll = context.WithCancel(l.ctx)
+	st.mu.Unlock()
 
-	l.logger.Info("launching goroutines...")
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		l.poll()
+	defer func() {
+		st.mu.Lock()
+		st.stop()
+		st.running = false
+		close(st.done)
+		st.mu.Unlock()
 	}()
 
-	l.logger.Info("preparing to work with", "working pool size", l.workingPool)
-	wg.Add(int(l.workingPool))
-	for range l.workingPool {
-		go func() {
-			defer wg.Done()
-			l.answer()
-		}()
+	if l.offsets != nil {
+		offset, err := l.offsets.LoadOffset(l.ctx)
+		if err != nil {
+			return fmt.Errorf("can't load the offset: %w", err)
+		}
+		if offset > 0 {
+			l.offset = &offset
+		}
+	}
+
+	l.logger.Info("preparing to work with", "working pool size", l.workingPool, "ordered", l.ordered)
+	var opts []tgbot.PoolOption
+	if l.ordered {
+		opts = append(opts, tgbot.Ordered())
 	}
+	l.pool = tgbot.NewWorkerPool(int(l.workingPool), l.answer, opts...)
+	l.dropped = new(atomic.Int64)
+	l.dropped.Store(math.MaxInt64)
+
 	l.logger.Info("bot is online")
-	wg.Wait()
+	l.poll()
+	l.pool.Close()
+	// the updates dropped after Stop were never handled, so they must be received again
+	if d := l.dropped.Load(); d != math.MaxInt64 && (l.offset == nil || int(d) < *l.offset) {
+		l.commit(int(d))
+	}
+	if l.confirm {
+		l.confirmOffset()
+	}
+	l.logger.Info("bot is offline")
+	if l.err != nil {
+		return l.err
+	}
+	return ctx.Err()
 }
 
-// Stop safely stops the bot's goroutines and channels.
-func (l LongPollingBot) Stop() {
-	if l.cancel != nil {
-		l.cancel()
+// Stop stops the bot immediately: the bot stops polling for updates,
+// the [tgbot.Context] of the updates being handled is canceled
+// and the received updates that weren't handled yet are dropped without being confirmed,
+// so they are received again after a restart.
+func (l *LongPollingBot) Stop() {
+	st := l.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.running {
+		st.stop()
+	}
+}
+
+// Shutdown stops the bot gracefully: the bot stops polling for updates
+// and waits until the received updates are handled, then returns nil.
+// If ctx is done before that, the [tgbot.Context] of the updates being handled is canceled,
+// the rest of the updates are dropped, and Shutdown returns the error of ctx.
+func (l *LongPollingBot) Shutdown(ctx context.Context) error {
+	st := l.state
+	st.mu.Lock()
+	if !st.running {
+		st.mu.Unlock()
+		return nil
+	}
+	stop, stopPoll, done := st.stop, st.stopPoll, st.done
+	st.mu.Unlock()
+
+	l.logger.Info("shutting down...")
+	stopPoll()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		stop()
+		return ctx.Err()
+	}
+}
+
+// confirmOffset confirms the handled updates to the Telegram Bot API,
+// so they are not received again after a restart.
+func (l *LongPollingBot) confirmOffset() {
+	if l.offset == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(l.ctx), 5*time.Second)
+	defer cancel()
+	limit, timeout := 1, 0
+	g := GetUpdates{
+		Offset:         l.offset,
+		Limit:          &limit,
+		Timeout:        &timeout,
+		AllowedUpdates: l.allowedUpdates,
+	}
+	if _, err := gotely.Call(ctx, l.client, g); err != nil {
+		l.logger.Error("can't confirm the offset;", "offset", *l.offset, "err", err.Error())
 	}
-	close(l.chUpdate)
-	l.logger.Info("bot is offline")
 }
 
 func (l LongPollingBot) Validate() error {
@@ -131,17 +262,40 @@ func New(bot tgbot.Bot, opts ...Option) LongPollingBot {
 		timeout:        30,
 		allowedUpdates: nil,
 
+		backoff: gotely.RetryPolicy{
+			BaseDelay: time.Second,
+			MaxDelay:  time.Minute,
+		},
+
 		workingPool: 1,
 		logger:      *slog.Default(),
+		state:       &state{},
 	}
 	for _, opt := range opts {
 		opt(&lpb)
 	}
+	if lpb.client == nil {
+		lpb.client = tgbot.NewClient(bot)
+	}
 	return lpb
 }
 
+// Client returns the [gotely.Client] used by the bot to send requests to the Telegram Bot API.
+func (l LongPollingBot) Client() *gotely.Client {
+	return l.client
+}
+
 type Option func(*LongPollingBot)
 
+// WithClient sets the [gotely.Client] used to send requests to the Telegram Bot API.
+// Use it to share a single client between several bots.
+// Defaults to a client created with [tgbot.NewClient].
+func WithClient(c *gotely.Client) Option {
+	return func(lpb *LongPollingBot) {
+		lpb.client = c
+	}
+}
+
 // WithTimeout sets the timeout parameter
 // for sending the [GetUpdates] request.
 func WithTimeout(t int) Option {
@@ -166,8 +320,56 @@ func WithAllowedUpdates(u *[]string) Option {
 	}
 }
 
+// WithOffsetStore sets the [OffsetStore] the bot loads the offset from on Start,
+// and saves the offset to after receiving updates, or after acknowledging them with [WithAcknowledgement].
+func WithOffsetStore(s OffsetStore) Option {
+	return func(lpb *LongPollingBot) {
+		lpb.offsets = s
+	}
+}
+
+// WithAcknowledgement makes the bot confirm the received updates to the Telegram Bot API
+// only after they are acknowledged: handled without an error, or failed after retries more attempts.
+// The next updates are requested only when all of the previous ones are acknowledged,
+// so the updates interrupted by a crash or Stop are received again, at least once.
+// Handlers must tolerate receiving the same update more than once.
+func WithAcknowledgement(retries int) Option {
+	return func(lpb *LongPollingBot) {
+		lpb.ack = true
+		lpb.retries = retries
+	}
+}
+
+// WithBackoff sets the delays between repeated requests for updates after network or server errors.
+// The delay starts at base and is doubled with every failed request, up to max.
+// Defaults to 1 second and 1 minute.
+func WithBackoff(base, max time.Duration) Option {
+	return func(lpb *LongPollingBot) {
+		lpb.backoff = gotely.RetryPolicy{BaseDelay: base, MaxDelay: max}
+	}
+}
+
+// WithWebhookRemoval makes the bot delete the webhook with the deleteWebhook method
+// if it prevents receiving updates with long polling.
+// Without it, the bot stops with an error.
+func WithWebhookRemoval() Option {
+	return func(lpb *LongPollingBot) {
+		lpb.deleteWebhook = true
+	}
+}
+
+// WithFinalConfirmation makes the bot confirm the offset of the last handled update
+// with one more [GetUpdates] request after it's stopped,
+// so the handled updates are not received again after a restart.
+func WithFinalConfirmation() Option {
+	return func(lpb *LongPollingBot) {
+		lpb.confirm = true
+	}
+}
+
 // WithWorkingPool sets the size of the bot's worker pool.
 // Defaults to 1.
+// Unless [WithOrderedProcessing] is used, the updates from the same chat can be handled concurrently.
 func WithWorkingPool(p uint) Option {
 	return func(lpb *LongPollingBot) {
 		if p == 0 {
@@ -178,10 +380,20 @@ func WithWorkingPool(p uint) Option {
 	}
 }
 
-func (l LongPollingBot) poll() {
+// WithOrderedProcessing makes the bot's worker pool handle the updates from the same chat or user
+// one after another, in the order they were received, while still handling different chats concurrently.
+// See [tgbot.Ordered].
+func WithOrderedProcessing() Option {
+	return func(lpb *LongPollingBot) {
+		lpb.ordered = true
+	}
+}
+
+func (l *LongPollingBot) poll() {
+	attempt := 0
 	for {
 		select {
-		case <-l.ctx.Done():
+		case <-l.pollCtx.Done():
 			l.logger.Info("exiting polling loop")
 			return
 
@@ -192,16 +404,11 @@ func (l LongPollingBot) poll() {
 				Timeout:        &l.timeout,
 				AllowedUpdates: l.allowedUpdates,
 			}
-			var upds []objects.Update
-			err := gotely.SendRequestWith(
-				g,
-				l.Bot.Token(),
-				&upds,
-				gotely.WithClient(l.Bot.Client()),
-				gotely.WithContext(l.ctx),
-				gotely.WithUrl(l.Bot.ApiURLTemplate()),
-			)
+			upds, err := gotely.Call(l.pollCtx, l.client, g)
 			if err != nil {
+				if l.pollCtx.Err() != nil {
+					continue
+				}
 				l.logger.Error("error while requesting for new updates;",
 					"err", err.Error(),
 					"offset", g.Offset,
@@ -209,40 +416,177 @@ func (l LongPollingBot) poll() {
 					"timeout", g.Timeout,
 					"allowed_updates", g.AllowedUpdates,
 				)
+				delay, err := l.classify(attempt, err)
+				if err != nil {
+					l.err = err
+					l.logger.Error("exiting polling loop;", "err", err.Error())
+					return
+				}
+				attempt++
+				l.logger.Info("requesting for new updates again after", "delay", delay)
+				t := time.NewTimer(delay)
+				select {
+				case <-l.pollCtx.Done():
+					t.Stop()
+				case <-t.C:
+				}
 				continue
 			}
+			attempt = 0
 
-			if len(upds) > 0 {
-				for _, upd := range upds {
-					select {
-					case l.chUpdate <- upd:
-						l.logger.Info("new incoming update;", "update_id", upd.UpdateId)
-						offset := upd.UpdateId + 1
-						l.offset = &offset
-					case <-l.ctx.Done():
-						l.logger.Info("exiting polling loop")
-						return
-					}
+			if len(upds) == 0 {
+				continue
+			}
+			if l.ack {
+				if !l.acknowledge(upds) {
+					l.logger.Info("exiting polling loop")
+					return
 				}
+				continue
+			}
+			if !l.submit(upds) {
+				l.logger.Info("exiting polling loop")
+				return
 			}
 		}
 	}
 }
 
-func (l *LongPollingBot) answer() {
+// classify decides what to do after the request for updates failed with err, counting attempts from zero.
+// It returns how long to wait before the next request, or the error that the bot can't recover from:
+// an invalid or revoked token, or a conflict with a webhook or another instance of the bot.
+func (l *LongPollingBot) classify(attempt int, err error) (time.Duration, error) {
+	var apiErr gotely.ErrTelegramAPIFailedRequest
+	if !errors.As(err, &apiErr) {
+		return l.backoff.Backoff(attempt), nil
+	}
+	switch {
+	case apiErr.Code == http.StatusTooManyRequests:
+		if p := apiErr.ResponseParameters; p != nil && p.RetryAfter != nil {
+			return time.Duration(*p.RetryAfter) * time.Second, nil
+		}
+		return l.backoff.Backoff(attempt), nil
+
+	case apiErr.Code >= http.StatusInternalServerError:
+		return l.backoff.Backoff(attempt), nil
+
+	case apiErr.Code == http.StatusConflict && l.deleteWebhook && !l.webhookDeleted &&
+		strings.Contains(apiErr.Description, "webhook"):
+		l.logger.Warn("webhook is active, deleting it...")
+		if _, err := gotely.Call(l.pollCtx, l.client, deleteWebhook{}); err != nil {
+			return 0, fmt.Errorf("can't delete the webhook: %w", err)
+		}
+		l.webhookDeleted = true
+		return 0, nil
+
+	case apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusNotFound:
+		return 0, fmt.Errorf("the token is invalid or was revoked: %w", err)
+
+	case apiErr.Code == http.StatusConflict:
+		return 0, fmt.Errorf("the bot can't receive updates with long polling: %w", err)
+	}
+	return 0, fmt.Errorf("the request for updates was rejected: %w", err)
+}
+
+// submit queues upds to be handled and commits the offset of the queued ones.
+// It reports whether all of upds were queued.
+func (l *LongPollingBot) submit(upds []objects.Update) bool {
+	n := 0
+	for _, upd := range upds {
+		if err := l.pool.Submit(l.pollCtx, upd, nil); err != nil {
+			break
+		}
+		l.logger.Info("new incoming update;", "update_id", upd.UpdateId)
+		n++
+	}
+	if n > 0 {
+		l.commit(upds[n-1].UpdateId + 1)
+	}
+	return n == len(upds)
+}
+
+// acknowledge handles upds and waits until they are acknowledged,
+// so the next request for updates, confirming them to the Telegram Bot API, is only sent after that.
+// An update is acknowledged when it's handled without an error, or after the retries are exhausted.
+// The offset is committed up to the first update that wasn't acknowledged because the bot was stopped.
+// It reports whether all of upds were acknowledged.
+func (l *LongPollingBot) acknowledge(upds []objects.Update) bool {
+	acked := make([]bool, len(upds))
+	wg := &sync.WaitGroup{}
+	for i, upd := range upds {
+		wg.Add(1)
+		err := l.pool.Submit(l.pollCtx, upd, func(err error) {
+			defer wg.Done()
+			// interrupted by Stop, so the update must be received again
+			acked[i] = l.ctx.Err() == nil || !errors.Is(err, context.Canceled)
+		})
+		if err != nil {
+			wg.Done()
+			break
+		}
+		l.logger.Info("new incoming update;", "update_id", upd.UpdateId)
+	}
+	wg.Wait()
+
+	n := 0
+	for n < len(acked) && acked[n] {
+		n++
+	}
+	if n > 0 {
+		l.commit(upds[n-1].UpdateId + 1)
+	}
+	return n == len(upds)
+}
+
+// commit sets the offset for the next request for updates and saves it to the [OffsetStore], if there is one.
+func (l *LongPollingBot) commit(offset int) {
+	l.offset = &offset
+	if l.offsets == nil {
+		return
+	}
+	// saving the offset even if the bot is being stopped
+	if err := l.offsets.SaveOffset(context.WithoutCancel(l.ctx), offset); err != nil {
+		l.logger.Error("can't save the offset;", "offset", offset, "err", err.Error())
+	}
+}
+
+// drop records that upd was dropped after Stop, so the offset isn't committed past it.
+func (l *LongPollingBot) drop(upd objects.Update) {
+	id := int64(upd.UpdateId)
 	for {
-		select {
-		case <-l.ctx.Done():
-			l.logger.Info("exiting answering loop")
+		d := l.dropped.Load()
+		if d <= id || l.dropped.CompareAndSwap(d, id) {
 			return
+		}
+	}
+}
 
-		case upd := <-l.chUpdate:
-			err := l.Bot.OnUpdate(upd)
-			if err != nil {
-				l.logger.Error("error while answering to an update;", "update_id", upd.UpdateId, "err", err.Error())
-				continue
-			}
-			l.logger.Info("done answering to update", "update_id", upd.UpdateId)
+func (l *LongPollingBot) answer(upd objects.Update) error {
+	// the updates left in the queue are dropped after Stop
+	if err := l.ctx.Err(); err != nil {
+		l.drop(upd)
+		return err
+	}
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = l.handle(upd)
+		if err == nil || !l.ack || attempt >= l.retries || l.ctx.Err() != nil {
+			break
 		}
+		l.logger.Warn("retrying to answer to an update;", "update_id", upd.UpdateId, "attempt", attempt+1, "err", err.Error())
+	}
+	if err != nil {
+		l.logger.Error("error while answering to an update;", "update_id", upd.UpdateId, "err", err.Error())
+		return err
+	}
+	l.logger.Info("done answering to update", "update_id", upd.UpdateId)
+	return nil
+}
+
+// handle passes upd to the bot, with a [tgbot.Context] canceled on Stop if the bot implements [tgbot.ContextBot].
+func (l *LongPollingBot) handle(upd objects.Update) error {
+	if b, ok := l.Bot.(tgbot.ContextBot); ok {
+		return b.OnUpdateContext(tgbot.NewContext(l.ctx, upd, l.client, &l.logger))
 	}
+	return l.Bot.OnUpdate(upd)
 }
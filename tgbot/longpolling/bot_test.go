@@ -0,0 +1,249 @@
+package longpolling_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+	"github.com/bigelle/gotely/tgbot/longpolling"
+)
+
+type flakyBot struct {
+	tgbot.DefaultBot
+	handled *[]int
+}
+
+func (b flakyBot) Token() string {
+	return "MOCK_TOKEN"
+}
+
+func (b flakyBot) OnUpdate(upd objects.Update) error {
+	*b.handled = append(*b.handled, upd.UpdateId)
+	if len(*b.handled) == 2 {
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+func TestAcknowledgement(t *testing.T) {
+	store := longpolling.NewFileOffsetStore(filepath.Join(t.TempDir(), "offset"))
+	if err := store.SaveOffset(context.Background(), 10); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	var (
+		handled []int
+		offsets []int
+		lb      longpolling.LongPollingBot
+	)
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			g := body.(longpolling.GetUpdates)
+			offsets = append(offsets, *g.Offset)
+			if len(offsets) == 1 {
+				return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[{"update_id":10},{"update_id":11}]`)}, nil
+			}
+			lb.Stop()
+			return nil, ctx.Err()
+		}
+	}))
+
+	lb = longpolling.New(flakyBot{handled: &handled},
+		longpolling.WithClient(client),
+		longpolling.WithOffsetStore(store),
+		longpolling.WithAcknowledgement(1),
+	)
+	if err := lb.Start(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(offsets) != 2 || offsets[0] != 10 || offsets[1] != 12 {
+		t.Fatalf("expected updates to be requested with offsets 10 and 12, got %v", offsets)
+	}
+	if len(handled) != 3 || handled[2] != 11 {
+		t.Fatalf("expected the failed update to be retried, got %v", handled)
+	}
+	offset, err := store.LoadOffset(context.Background())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if offset != 12 {
+		t.Fatalf("expected offset 12 to be saved, got %d", offset)
+	}
+}
+
+type blockingBot struct {
+	tgbot.DefaultBot
+	started, release chan struct{}
+}
+
+func (b blockingBot) Token() string {
+	return "MOCK_TOKEN"
+}
+
+func (b blockingBot) OnUpdate(upd objects.Update) error {
+	close(b.started)
+	<-b.release
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		calls     int
+		confirmed *int
+	)
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			g := body.(longpolling.GetUpdates)
+			mu.Lock()
+			calls++
+			first := calls == 1
+			if *g.Timeout == 0 {
+				confirmed = g.Offset
+			}
+			mu.Unlock()
+
+			if first {
+				return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[{"update_id":1}]`)}, nil
+			}
+			if *g.Timeout == 0 {
+				return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[]`)}, nil
+			}
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}))
+
+	bot := blockingBot{started: make(chan struct{}), release: make(chan struct{})}
+	lb := longpolling.New(bot, longpolling.WithClient(client), longpolling.WithFinalConfirmation())
+	ran := make(chan error)
+	go func() {
+		ran <- lb.Run(context.Background())
+	}()
+
+	<-bot.started
+	shut := make(chan error)
+	go func() {
+		shut <- lb.Shutdown(context.Background())
+	}()
+	select {
+	case <-shut:
+		t.Fatal("expected Shutdown to wait for the update being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(bot.release)
+	if err := <-shut; err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := <-ran; err != nil {
+		t.Fatal(err.Error())
+	}
+	if confirmed == nil || *confirmed != 2 {
+		t.Fatalf("expected the offset 2 to be confirmed, got %v", confirmed)
+	}
+}
+
+func TestPollingErrors(t *testing.T) {
+	failure := func(code int, description string) *gotely.ApiResponse {
+		return &gotely.ApiResponse{Ok: false, ErrorCode: &code, Description: &description}
+	}
+	var endpoints []string
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			endpoints = append(endpoints, body.Endpoint())
+			switch len(endpoints) {
+			case 1:
+				return failure(502, "Bad Gateway"), nil
+			case 2:
+				return failure(409, "Conflict: can't use getUpdates method while webhook is active; use deleteWebhook to delete the webhook first"), nil
+			case 3:
+				return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`true`)}, nil
+			}
+			return failure(401, "Unauthorized"), nil
+		}
+	}))
+
+	lb := longpolling.New(blockingBot{},
+		longpolling.WithClient(client),
+		longpolling.WithBackoff(time.Millisecond, time.Millisecond),
+		longpolling.WithWebhookRemoval(),
+	)
+	err := lb.Run(context.Background())
+
+	var apiErr gotely.ErrTelegramAPIFailedRequest
+	if !errors.As(err, &apiErr) || apiErr.Code != 401 {
+		t.Fatalf("expected the bot to stop with error 401, got %v", err)
+	}
+	expected := []string{"getUpdates", "getUpdates", "deleteWebhook", "getUpdates"}
+	if !slices.Equal(endpoints, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, endpoints)
+	}
+}
+
+type stoppingBot struct {
+	tgbot.DefaultBot
+	lb      *longpolling.LongPollingBot
+	handled *[]int
+}
+
+func (b stoppingBot) Token() string {
+	return "MOCK_TOKEN"
+}
+
+func (b stoppingBot) OnUpdate(upd objects.Update) error {
+	*b.handled = append(*b.handled, upd.UpdateId)
+	b.lb.Stop()
+	return nil
+}
+
+func TestStopDropsUpdates(t *testing.T) {
+	store := longpolling.NewFileOffsetStore(filepath.Join(t.TempDir(), "offset"))
+	var confirmed *int
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			g := body.(longpolling.GetUpdates)
+			if *g.Timeout == 0 {
+				confirmed = g.Offset
+				return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[]`)}, nil
+			}
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`[{"update_id":10},{"update_id":11},{"update_id":12}]`)}, nil
+		}
+	}))
+
+	var (
+		handled []int
+		lb      longpolling.LongPollingBot
+	)
+	lb = longpolling.New(stoppingBot{lb: &lb, handled: &handled},
+		longpolling.WithClient(client),
+		longpolling.WithOffsetStore(store),
+		longpolling.WithFinalConfirmation(),
+	)
+	if err := lb.Run(context.Background()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !slices.Equal(handled, []int{10}) {
+		t.Fatalf("expected only update 10 to be handled, got %v", handled)
+	}
+	if confirmed == nil || *confirmed != 11 {
+		t.Fatalf("expected the offset 11 to be confirmed, got %v", confirmed)
+	}
+	offset, err := store.LoadOffset(context.Background())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if offset != 11 {
+		t.Fatalf("expected offset 11 to be saved, got %d", offset)
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"io"
 
 	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/objects"
 )
 
 // Use this method to receive incoming updates using long polling
 // (https://en.wikipedia.org/wiki/Push_technology#Long_polling).
 // Returns an Array of [objects.Update] objects.
 type GetUpdates struct {
+	gotely.Returns[[]objects.Update]
+
 	// Identifier of the first update to be returned.
 	// Must be greater by one than the highest among the identifiers of previously received updates.
 	// By default, updates starting with the earliest unconfirmed update are returned.
@@ -95,3 +98,24 @@ func (g GetUpdates) Reader() io.Reader {
 func (g GetUpdates) ContentType() string {
 	return "application/json"
 }
+
+// deleteWebhook is the deleteWebhook method, sent to switch the bot from a webhook to long polling.
+type deleteWebhook struct {
+	gotely.Returns[bool]
+}
+
+func (d deleteWebhook) Endpoint() string {
+	return "deleteWebhook"
+}
+
+func (d deleteWebhook) Validate() error {
+	return nil
+}
+
+func (d deleteWebhook) Reader() io.Reader {
+	return gotely.EncodeJSON(d)
+}
+
+func (d deleteWebhook) ContentType() string {
+	return "application/json"
+}
@@ -0,0 +1,52 @@
+package longpolling
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bigelle/gotely/internal/atomicfile"
+)
+
+// OffsetStore persists the offset of the next update the bot should receive,
+// so the updates that were already handled are not received again after a restart.
+// Implementations must be safe for concurrent use.
+type OffsetStore interface {
+	// LoadOffset returns the saved offset, or 0 if there is none.
+	LoadOffset(ctx context.Context) (int, error)
+	// SaveOffset saves offset.
+	SaveOffset(ctx context.Context, offset int) error
+}
+
+// FileOffsetStore is an [OffsetStore] keeping the offset in a text file.
+type FileOffsetStore struct {
+	path string
+}
+
+// NewFileOffsetStore creates a new [FileOffsetStore] keeping the offset in the file at path.
+// The file is created on the first save.
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{path: path}
+}
+
+func (f *FileOffsetStore) LoadOffset(_ context.Context) (int, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func (f *FileOffsetStore) SaveOffset(_ context.Context, offset int) error {
+	return atomicfile.Write(f.path, []byte(strconv.Itoa(offset)))
+}
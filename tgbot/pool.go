@@ -0,0 +1,150 @@
+package tgbot
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+// ErrPoolClosed is returned by [WorkerPool.Submit] after the pool was closed.
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// WorkerPool handles updates concurrently on a fixed number of workers.
+//
+// By default, the workers take updates from a single queue in the order they were submitted,
+// so the updates from the same chat can be handled concurrently and finish out of order.
+// With [Ordered], the updates are sharded by [OrderKey] onto the workers, so the updates
+// from the same chat or user are handled one after another, in the order they were submitted,
+// while the updates from different chats are still handled concurrently.
+type WorkerPool struct {
+	handle    func(objects.Update) error
+	queues    []chan job
+	ordered   bool
+	queueSize int
+	// for the updates without an order key
+	next atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type job struct {
+	upd  objects.Update
+	done func(error)
+}
+
+type PoolOption func(*WorkerPool)
+
+// WithQueueSize sets how many submitted updates can wait in each queue of the pool
+// before [WorkerPool.Submit] blocks.
+// Defaults to the number of workers, or 1 for every worker of an [Ordered] pool.
+func WithQueueSize(n int) PoolOption {
+	return func(p *WorkerPool) {
+		p.queueSize = n
+	}
+}
+
+// Ordered makes the pool keep the order of the updates from the same chat or user.
+func Ordered() PoolOption {
+	return func(p *WorkerPool) {
+		p.ordered = true
+	}
+}
+
+// NewWorkerPool starts a new [WorkerPool] with the given number of workers,
+// calling handle for every submitted update.
+// If workers is less than 1, the pool has 1 worker.
+func NewWorkerPool(workers int, handle func(objects.Update) error, opts ...PoolOption) *WorkerPool {
+	workers = max(workers, 1)
+	p := &WorkerPool{handle: handle}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.ordered {
+		p.queues = make([]chan job, workers)
+		for i := range p.queues {
+			p.queues[i] = make(chan job, cmp.Or(p.queueSize, 1))
+		}
+	} else {
+		p.queues = []chan job{make(chan job, cmp.Or(p.queueSize, workers))}
+	}
+
+	p.wg.Add(workers)
+	for i := range workers {
+		q := p.queues[i%len(p.queues)]
+		go func() {
+			defer p.wg.Done()
+			for j := range q {
+				err := p.handle(j.upd)
+				if j.done != nil {
+					j.done(err)
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// Submit queues upd to be handled by the pool, waiting while the queue is full.
+// done, if not nil, is called by the worker with the error returned by the handler.
+// Submit returns the error of ctx if it's done before upd is queued,
+// or [ErrPoolClosed] if the pool was closed.
+func (p *WorkerPool) Submit(ctx context.Context, upd objects.Update, done func(error)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.queue(upd) <- job{upd: upd, done: done}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// queue returns the queue upd should be submitted to.
+func (p *WorkerPool) queue(upd objects.Update) chan job {
+	if len(p.queues) == 1 {
+		return p.queues[0]
+	}
+	n := uint64(len(p.queues))
+	key, ok := OrderKey(upd)
+	if !ok {
+		return p.queues[p.next.Add(1)%n]
+	}
+	return p.queues[uint64(key)%n]
+}
+
+// Close stops accepting new updates and waits until the queued updates are handled.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, q := range p.queues {
+			close(q)
+		}
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// OrderKey returns the key used by an ordered [WorkerPool] to keep the order of updates:
+// the id of the chat the update came from, or the id of the user who caused it.
+// It reports false if the update has neither, for example a poll update.
+func OrderKey(upd objects.Update) (int64, bool) {
+	if chat := ChatOf(upd); chat != nil {
+		return chat.Id, true
+	}
+	if user := SenderOf(upd); user != nil {
+		return user.Id, true
+	}
+	return 0, false
+}
@@ -0,0 +1,57 @@
+package tgbot_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+func TestOrderedWorkerPool(t *testing.T) {
+	var mu sync.Mutex
+	got := map[int64][]int{}
+	busy := map[int64]bool{}
+
+	pool := tgbot.NewWorkerPool(3, func(upd objects.Update) error {
+		chat := upd.Message.Chat.Id
+		mu.Lock()
+		if busy[chat] {
+			t.Errorf("updates from chat %d are handled concurrently", chat)
+		}
+		busy[chat] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		busy[chat] = false
+		got[chat] = append(got[chat], upd.UpdateId)
+		mu.Unlock()
+		return nil
+	}, tgbot.Ordered())
+
+	for i := range 30 {
+		upd := objects.Update{
+			UpdateId: i,
+			Message:  &objects.Message{Chat: objects.Chat{Id: int64(i % 4)}},
+		}
+		if err := pool.Submit(context.Background(), upd, nil); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	pool.Close()
+
+	for chat, ids := range got {
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Fatalf("updates from chat %d are handled out of order: %v", chat, ids)
+			}
+		}
+	}
+	if err := pool.Submit(context.Background(), objects.Update{}, nil); err != tgbot.ErrPoolClosed {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+}
@@ -0,0 +1,285 @@
+package tgbot
+
+import (
+	"context"
+	"slices"
+
+	"github.com/bigelle/gotely/objects"
+)
+
+// Router dispatches incoming updates to the handlers registered for their kind.
+// It implements the OnUpdate method of [Bot], so it can be embedded into a bot:
+//
+//	type MyBot struct {
+//		tgbot.DefaultBot
+//		*tgbot.Router
+//		token string
+//	}
+//
+//	func (b MyBot) Token() string {
+//		return b.token
+//	}
+//
+//	func main() {
+//		r := tgbot.NewRouter()
+//		r.OnMessage(func(msg objects.Message) error {
+//			// handling the message
+//			return nil
+//		})
+//		bot := MyBot{Router: r, token: "MY-SECRET-TOKEN"}
+//		lb := longpolling.New(bot)
+//		if err := lb.Start(); err != nil {
+//			panic(err)
+//		}
+//	}
+//
+// Handlers are tried in the order they were registered, and only the first matching one is called.
+// If no handler matches the update, the fallback handler is called, if there is one.
+// Router is not safe for registering handlers concurrently with handling updates,
+// so every handler should be registered before the bot is started.
+type Router struct {
+	routes   []route
+	fallback ContextHandler
+
+	username   string
+	commands   []command
+	middleware []Middleware
+
+	// set for the routers created with With
+	parent  *Router
+	filters []Filter
+}
+
+// Handler handles an incoming update.
+type Handler func(objects.Update) error
+
+// Middleware wraps a [ContextHandler], for example to load data before the update is handled
+// or to recover from panics.
+type Middleware func(next ContextHandler) ContextHandler
+
+type route struct {
+	match  Filter
+	handle ContextHandler
+}
+
+// NewRouter creates a new empty [Router].
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// OnUpdate calls the first handler matching upd,
+// or the fallback handler if there is no such handler.
+// Unmatched updates are ignored if there is no fallback handler.
+//
+// The handlers receive a [Context] without a client.
+// Bots use [Router.OnUpdateContext] instead, which is called by
+// [longpolling.LongPollingBot] and [webhook.WebhookBot].
+func (r *Router) OnUpdate(upd objects.Update) error {
+	return r.OnUpdateContext(NewContext(context.Background(), upd, nil, nil))
+}
+
+// OnUpdateContext is the same as [Router.OnUpdate], passing c to the handlers.
+func (r *Router) OnUpdateContext(c *Context) error {
+	if r.parent != nil {
+		return r.root().OnUpdateContext(c)
+	}
+	h := r.dispatch
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+	return h(c)
+}
+
+// Use adds middleware that wraps the dispatching of every update,
+// the first one being the outermost.
+func (r *Router) Use(m ...Middleware) {
+	root := r.root()
+	root.middleware = append(root.middleware, m...)
+}
+
+func (r *Router) dispatch(c *Context) error {
+	for _, rt := range r.routes {
+		if rt.match(c.Update) {
+			return rt.handle(c)
+		}
+	}
+	if r.fallback != nil {
+		return r.fallback(c)
+	}
+	return nil
+}
+
+// Handle registers a handler for every update matching match.
+// It can be used for the kinds of updates that have no dedicated method.
+func (r *Router) Handle(match Filter, h Handler) {
+	r.HandleContext(match, func(c *Context) error {
+		return h(c.Update)
+	})
+}
+
+// HandleContext registers a handler receiving the [Context] of every update matching match.
+func (r *Router) HandleContext(match Filter, h ContextHandler) {
+	if r.parent != nil {
+		r.parent.HandleContext(And(append(slices.Clip(r.filters), match)...), h)
+		return
+	}
+	r.routes = append(r.routes, route{match: match, handle: h})
+}
+
+// With returns a router registering handlers in r only for the updates matching every one of filters.
+// Filters are checked before the kind of the update, for example:
+//
+//	r.With(tgbot.PrivateChat, tgbot.HasPhoto).OnMessage(func(msg objects.Message) error {
+//		// handling photos sent to the bot in private chats
+//		return nil
+//	})
+func (r *Router) With(filters ...Filter) *Router {
+	return &Router{parent: r, filters: filters}
+}
+
+// Fallback sets the handler called for the updates that didn't match any other handler.
+func (r *Router) Fallback(h Handler) {
+	r.FallbackContext(func(c *Context) error {
+		return h(c.Update)
+	})
+}
+
+// FallbackContext is the same as [Router.Fallback] for a handler receiving the [Context] of the update.
+func (r *Router) FallbackContext(h ContextHandler) {
+	r.root().fallback = h
+}
+
+// root returns the router the handlers are registered in.
+func (r *Router) root() *Router {
+	for r.parent != nil {
+		r = r.parent
+	}
+	return r
+}
+
+// on registers h for the updates where get returns a non-nil payload.
+func on[T any](r *Router, get func(objects.Update) *T, h func(T) error) {
+	r.Handle(
+		func(upd objects.Update) bool {
+			return get(upd) != nil
+		},
+		func(upd objects.Update) error {
+			return h(*get(upd))
+		},
+	)
+}
+
+// OnMessage registers a handler for new incoming messages.
+func (r *Router) OnMessage(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.Message }, h)
+}
+
+// OnEditedMessage registers a handler for edited messages.
+func (r *Router) OnEditedMessage(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.EditedMessage }, h)
+}
+
+// OnChannelPost registers a handler for new channel posts.
+func (r *Router) OnChannelPost(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.ChannelPost }, h)
+}
+
+// OnEditedChannelPost registers a handler for edited channel posts.
+func (r *Router) OnEditedChannelPost(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.EditedChannelPost }, h)
+}
+
+// OnBusinessConnection registers a handler for connections to business accounts.
+func (r *Router) OnBusinessConnection(h func(objects.BusinessConnection) error) {
+	on(r, func(u objects.Update) *objects.BusinessConnection { return u.BusinessConnection }, h)
+}
+
+// OnBusinessMessage registers a handler for new messages from connected business accounts.
+func (r *Router) OnBusinessMessage(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.BusinessMessage }, h)
+}
+
+// OnEditedBusinessMessage registers a handler for edited messages from connected business accounts.
+func (r *Router) OnEditedBusinessMessage(h func(objects.Message) error) {
+	on(r, func(u objects.Update) *objects.Message { return u.EditedBusinessMessage }, h)
+}
+
+// OnDeletedBusinessMessages registers a handler for messages deleted from connected business accounts.
+func (r *Router) OnDeletedBusinessMessages(h func(objects.BusinessMessagesDeleted) error) {
+	on(r, func(u objects.Update) *objects.BusinessMessagesDeleted { return u.DeletedBusinessMessage }, h)
+}
+
+// OnMessageReaction registers a handler for changes of reactions to messages.
+func (r *Router) OnMessageReaction(h func(objects.MessageReactionUpdated) error) {
+	on(r, func(u objects.Update) *objects.MessageReactionUpdated { return u.MessageReaction }, h)
+}
+
+// OnMessageReactionCount registers a handler for changes of anonymous reactions to messages.
+func (r *Router) OnMessageReactionCount(h func(objects.MessageReactionCountUpdated) error) {
+	on(r, func(u objects.Update) *objects.MessageReactionCountUpdated { return u.MessageReactionCount }, h)
+}
+
+// OnInlineQuery registers a handler for inline queries.
+func (r *Router) OnInlineQuery(h func(objects.InlineQuery) error) {
+	on(r, func(u objects.Update) *objects.InlineQuery { return u.InlineQuery }, h)
+}
+
+// OnChosenInlineResult registers a handler for chosen inline results.
+func (r *Router) OnChosenInlineResult(h func(objects.ChosenInlineResult) error) {
+	on(r, func(u objects.Update) *objects.ChosenInlineResult { return u.ChosenInlineQuery }, h)
+}
+
+// OnCallbackQuery registers a handler for callback queries.
+func (r *Router) OnCallbackQuery(h func(objects.CallbackQuery) error) {
+	on(r, func(u objects.Update) *objects.CallbackQuery { return u.CallbackQuery }, h)
+}
+
+// OnShippingQuery registers a handler for shipping queries.
+func (r *Router) OnShippingQuery(h func(objects.ShippingQuery) error) {
+	on(r, func(u objects.Update) *objects.ShippingQuery { return u.ShippingQuery }, h)
+}
+
+// OnPreCheckoutQuery registers a handler for pre-checkout queries.
+func (r *Router) OnPreCheckoutQuery(h func(objects.PreCheckoutQuery) error) {
+	on(r, func(u objects.Update) *objects.PreCheckoutQuery { return u.PreCheckoutQuery }, h)
+}
+
+// OnPurchasedPaidMedia registers a handler for purchases of paid media.
+func (r *Router) OnPurchasedPaidMedia(h func(objects.PaidMediaPurchased) error) {
+	on(r, func(u objects.Update) *objects.PaidMediaPurchased { return u.PurchasedPaidMedia }, h)
+}
+
+// OnPoll registers a handler for changes of poll states.
+func (r *Router) OnPoll(h func(objects.Poll) error) {
+	on(r, func(u objects.Update) *objects.Poll { return u.Poll }, h)
+}
+
+// OnPollAnswer registers a handler for answers in non-anonymous polls.
+func (r *Router) OnPollAnswer(h func(objects.PollAnswer) error) {
+	on(r, func(u objects.Update) *objects.PollAnswer { return u.PollAnswer }, h)
+}
+
+// OnMyChatMember registers a handler for changes of the bot's chat member status.
+func (r *Router) OnMyChatMember(h func(objects.ChatMemberUpdated) error) {
+	on(r, func(u objects.Update) *objects.ChatMemberUpdated { return u.MyChatMember }, h)
+}
+
+// OnChatMember registers a handler for changes of chat members' statuses.
+func (r *Router) OnChatMember(h func(objects.ChatMemberUpdated) error) {
+	on(r, func(u objects.Update) *objects.ChatMemberUpdated { return u.ChatMember }, h)
+}
+
+// OnChatJoinRequest registers a handler for requests to join a chat.
+func (r *Router) OnChatJoinRequest(h func(objects.ChatJoinRequest) error) {
+	on(r, func(u objects.Update) *objects.ChatJoinRequest { return u.ChatJoinRequest }, h)
+}
+
+// OnChatBoost registers a handler for added or changed chat boosts.
+func (r *Router) OnChatBoost(h func(objects.ChatBoostUpdated) error) {
+	on(r, func(u objects.Update) *objects.ChatBoostUpdated { return u.ChatBoost }, h)
+}
+
+// OnRemovedChatBoost registers a handler for removed chat boosts.
+func (r *Router) OnRemovedChatBoost(h func(objects.ChatBoostRemoved) error) {
+	on(r, func(u objects.Update) *objects.ChatBoostRemoved { return u.RemovedChatBoost }, h)
+}
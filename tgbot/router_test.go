@@ -0,0 +1,139 @@
+package tgbot_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+func TestRouter(t *testing.T) {
+	var got []string
+	r := tgbot.NewRouter()
+	r.OnMessage(func(msg objects.Message) error {
+		got = append(got, "message")
+		return nil
+	})
+	r.OnCallbackQuery(func(cq objects.CallbackQuery) error {
+		got = append(got, "callback_query:"+cq.Id)
+		return nil
+	})
+	r.Fallback(func(upd objects.Update) error {
+		got = append(got, "fallback")
+		return nil
+	})
+
+	updates := []objects.Update{
+		{UpdateId: 1, Message: &objects.Message{MessageId: 1}},
+		{UpdateId: 2, CallbackQuery: &objects.CallbackQuery{Id: "42"}},
+		{UpdateId: 3, InlineQuery: &objects.InlineQuery{}},
+	}
+	for _, upd := range updates {
+		if err := r.OnUpdate(upd); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	expected := []string{"message", "callback_query:42", "fallback"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	message := func(text string, length int) objects.Update {
+		return objects.Update{Message: &objects.Message{
+			Text:     &text,
+			Entities: &[]objects.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}},
+		}}
+	}
+
+	var got []string
+	r := tgbot.NewRouter()
+	r.SetUsername("@our_bot")
+	r.OnStart("", func(cmd tgbot.Command) error {
+		got = append(got, cmd.Name+":"+cmd.Args)
+		return nil
+	})
+	r.OnCommand("help", "shows help", func(cmd tgbot.Command) error {
+		got = append(got, cmd.Name)
+		return nil
+	})
+	r.Fallback(func(upd objects.Update) error {
+		got = append(got, "fallback")
+		return nil
+	})
+
+	updates := []objects.Update{
+		message("/start@our_bot ref_42", 14),
+		message("/help@other_bot", 15),
+		message("/HELP 🙂", 5),
+	}
+	for _, upd := range updates {
+		if err := r.OnUpdate(upd); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	expected := []string{"start:ref_42", "fallback", "HELP"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestFilters(t *testing.T) {
+	text := func(chatType, s string) objects.Update {
+		return objects.Update{Message: &objects.Message{
+			Chat: objects.Chat{Id: 1, Type: chatType},
+			From: &objects.User{Id: 42},
+			Text: &s,
+		}}
+	}
+
+	var got []string
+	r := tgbot.NewRouter()
+	r.With(tgbot.PrivateChat, tgbot.TextMatches(regexp.MustCompile(`^\d+$`))).OnMessage(func(msg objects.Message) error {
+		got = append(got, "number")
+		return nil
+	})
+	r.With(tgbot.Or(tgbot.Supergroup, tgbot.Not(tgbot.FromUser(42)))).OnMessage(func(msg objects.Message) error {
+		got = append(got, "supergroup")
+		return nil
+	})
+	r.OnMessage(func(msg objects.Message) error {
+		got = append(got, "other")
+		return nil
+	})
+
+	updates := []objects.Update{
+		text("private", "123"),
+		text("supergroup", "123"),
+		text("private", "hello"),
+	}
+	for _, upd := range updates {
+		if err := r.OnUpdate(upd); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	expected := []string{"number", "supergroup", "other"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+)
+
+// SendText sends m, splitting its text into several messages with [format.Split]
+// if it's longer than [format.MaxTextLen], and returns the sent messages in order.
+//
+// Every message is sent to the same chat, topic and business connection,
+// only the first one replies to [methods.SendMessage.ReplyParameters] and has the message effect,
+// and only the last one has the [methods.SendMessage.ReplyMarkup].
+// A long text can't be split if it uses a parse mode, so entities should be used instead,
+// for example with [format.Builder].
+//
+// If sending a message fails, the messages sent before it are returned with the error.
+func SendText(ctx context.Context, c *gotely.Client, m methods.SendMessage) ([]objects.Message, error) {
+	if format.Len(m.Text) <= format.MaxTextLen {
+		msg, err := gotely.Call(ctx, c, m)
+		if err != nil {
+			return nil, err
+		}
+		return []objects.Message{msg}, nil
+	}
+	if m.ParseMode != nil {
+		return nil, fmt.Errorf("can't split a text formatted with parse mode %s, use entities instead", *m.ParseMode)
+	}
+
+	var entities []objects.MessageEntity
+	if m.Entities != nil {
+		entities = *m.Entities
+	}
+	chunks := format.Split(m.Text, entities, format.MaxTextLen)
+	sent := make([]objects.Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := m
+		part.Text = chunk.Text
+		part.Entities = nil
+		if len(chunk.Entities) > 0 {
+			part.Entities = &chunk.Entities
+		}
+		if i > 0 {
+			part.ReplyParameters = nil
+			part.MessageEffectId = nil
+		}
+		if i < len(chunks)-1 {
+			part.ReplyMarkup = nil
+		}
+		msg, err := gotely.Call(ctx, c, part)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, msg)
+	}
+	return sent, nil
+}
+
+// SendCaption sends media, such as [methods.SendPhoto], with text as its caption,
+// splitting it with [format.SplitCaption] if it's longer than [format.MaxCaptionLen],
+// and returns the sent messages in order, the media being the first one.
+// setCaption returns media with the caption set to the first part of the text,
+// and the rest is sent as messages following the media in the same chat, topic and business connection.
+// The reply markup of media, if any, stays on the media.
+//
+// If sending a message fails, the messages sent before it are returned with the error.
+func SendCaption[M gotely.MethodOf[objects.Message]](ctx context.Context, c *gotely.Client, media M,
+	text string, entities []objects.MessageEntity, setCaption func(M, format.Chunk) M) ([]objects.Message, error) {
+	caption, rest := format.SplitCaption(text, entities)
+	media = setCaption(media, caption)
+	msg, err := gotely.Call[objects.Message](ctx, c, media)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make([]objects.Message, 0, len(rest)+1)
+	sent = append(sent, msg)
+	for _, chunk := range rest {
+		part := methods.SendMessage{
+			ChatId:               fmt.Sprint(msg.Chat.Id),
+			Text:                 chunk.Text,
+			BusinessConnectionId: msg.BusinessConnectionId,
+		}
+		if msg.IsTopicMessage != nil && *msg.IsTopicMessage {
+			part.MessageThreadId = msg.MessageThreadId
+		}
+		if len(chunk.Entities) > 0 {
+			part.Entities = &chunk.Entities
+		}
+		m, err := gotely.Call(ctx, c, part)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, m)
+	}
+	return sent, nil
+}
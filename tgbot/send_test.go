@@ -0,0 +1,84 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bigelle/gotely"
+	"github.com/bigelle/gotely/format"
+	"github.com/bigelle/gotely/methods"
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+func TestSendText(t *testing.T) {
+	var sent []methods.SendMessage
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			m := body.(methods.SendMessage)
+			sent = append(sent, m)
+			b, _ := json.Marshal(objects.Message{MessageId: len(sent), Text: &m.Text})
+			return &gotely.ApiResponse{Ok: true, Result: b}, nil
+		}
+	}))
+
+	// 3 paragraphs of 2000 Cyrillic letters each, 4000 bytes long
+	paragraph := strings.Repeat("я", 2000)
+	markup := objects.ReplyMarkup{ReplyMarkupInterface: objects.ReplyKeyboardRemove{RemoveKeyboard: true}}
+	msgs, err := tgbot.SendText(context.Background(), client, methods.SendMessage{
+		ChatId:          "1",
+		Text:            strings.Join([]string{paragraph, paragraph, paragraph}, "\n\n"),
+		ReplyParameters: &objects.ReplyParameters{MessageId: 42},
+		ReplyMarkup:     &markup,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(msgs) != 2 || msgs[1].MessageId != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(msgs))
+	}
+	if sent[0].ReplyParameters == nil || sent[1].ReplyParameters != nil {
+		t.Fatal("expected only the first message to be a reply")
+	}
+	if sent[0].ReplyMarkup != nil || sent[1].ReplyMarkup == nil {
+		t.Fatal("expected only the last message to have the reply markup")
+	}
+}
+
+func TestSendCaption(t *testing.T) {
+	var captions, texts []string
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			switch m := body.(type) {
+			case methods.SendMessage:
+				texts = append(texts, m.Text)
+			case *methods.SendPhoto:
+				captions = append(captions, *m.Caption)
+			default:
+				t.Fatalf("unexpected request %s", endpoint)
+			}
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}`)}, nil
+		}
+	}))
+
+	text := strings.Repeat("я", 1000) + "\n\n" + strings.Repeat("я", 1000)
+	msgs, err := tgbot.SendCaption(context.Background(), client,
+		&methods.SendPhoto{ChatId: "1", Photo: objects.InputFileFromRemote("file_id")},
+		text, nil,
+		func(m *methods.SendPhoto, caption format.Chunk) *methods.SendPhoto {
+			m.Caption = &caption.Text
+			return m
+		},
+	)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(msgs) != 2 || len(captions) != 1 || len(texts) != 1 {
+		t.Fatalf("expected the photo and a message, got %d captions and %d texts", len(captions), len(texts))
+	}
+	if format.Len(captions[0]) > format.MaxCaptionLen || captions[0]+"\n\n"+texts[0] != text {
+		t.Fatal("unexpected split of the caption")
+	}
+}
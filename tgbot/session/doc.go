@@ -0,0 +1,7 @@
+// This package provides typed per-user and per-chat sessions for the bot's handlers.
+// A session is loaded before the handler is called and saved back after it returns,
+// using optimistic concurrency, so several workers handling updates at the same time
+// don't overwrite each other's changes.
+//
+// Licensed under the MIT License. See LICENSE file for details.
+package session
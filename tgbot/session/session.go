@@ -0,0 +1,236 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+)
+
+// KeyFunc returns the key of the session an update belongs to.
+// It reports false if the update has no session.
+type KeyFunc func(objects.Update) (string, bool)
+
+// ByUser keeps a session for every user, as returned by [tgbot.SenderOf].
+// It's the default [KeyFunc].
+func ByUser(upd objects.Update) (string, bool) {
+	u := tgbot.SenderOf(upd)
+	if u == nil {
+		return "", false
+	}
+	return fmt.Sprintf("user:%d", u.Id), true
+}
+
+// ByChat keeps a session for every chat, as returned by [tgbot.ChatOf].
+func ByChat(upd objects.Update) (string, bool) {
+	c := tgbot.ChatOf(upd)
+	if c == nil {
+		return "", false
+	}
+	return fmt.Sprintf("chat:%d", c.Id), true
+}
+
+// ByChatAndUser keeps a session for every user in every chat.
+func ByChatAndUser(upd objects.Update) (string, bool) {
+	c, u := tgbot.ChatOf(upd), tgbot.SenderOf(upd)
+	if c == nil || u == nil {
+		return "", false
+	}
+	return fmt.Sprintf("chat:%d:user:%d", c.Id, u.Id), true
+}
+
+// Manager loads and saves sessions of type T.
+// T must be JSON-serializable.
+//
+// Example:
+//
+//	type Settings struct {
+//		Language string
+//	}
+//
+//	settings := session.New[Settings](session.NewMemoryStore())
+//	r := tgbot.NewRouter()
+//	r.Use(settings.Middleware)
+//	r.OnMessage(func(msg objects.Message) error {
+//		s := settings.Of(objects.Update{Message: &msg})
+//		s.Language = "en"
+//		return nil
+//	})
+type Manager[T any] struct {
+	store   Store
+	key     KeyFunc
+	retries int
+
+	mu sync.Mutex
+	// sessions being handled, by key
+	active map[string]*active[T]
+}
+
+type active[T any] struct {
+	// serializes handling of the same session within the process
+	mu sync.Mutex
+	// number of updates holding or waiting for mu
+	refs    int
+	session *T
+}
+
+// New creates a new [Manager] keeping sessions in store.
+func New[T any](store Store, opts ...Option) *Manager[T] {
+	cfg := config{key: ByUser}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Manager[T]{
+		store:   store,
+		key:     cfg.key,
+		retries: cfg.retries,
+		active:  make(map[string]*active[T]),
+	}
+}
+
+type config struct {
+	key     KeyFunc
+	retries int
+}
+
+type Option func(*config)
+
+// WithKey sets the function returning the key of the session an update belongs to.
+// Defaults to [ByUser].
+func WithKey(f KeyFunc) Option {
+	return func(c *config) {
+		c.key = f
+	}
+}
+
+// WithRetries sets how many times the handler is called again with the reloaded session
+// if the session was modified concurrently. Handlers with side effects, like sending messages,
+// will repeat them, so it should only be used with idempotent handlers.
+// Defaults to 0, meaning that [ErrConflict] is returned.
+func WithRetries(n int) Option {
+	return func(c *config) {
+		c.retries = n
+	}
+}
+
+// Middleware loads the session of every update before calling next,
+// and saves it back after next returns without an error.
+// Updates with the same session are handled one at a time within the process,
+// while concurrent changes made by other processes sharing the [Store] are detected by its version.
+// The session is available to the handlers with [Manager.Of].
+// It returns [ErrConflict] if the session was modified concurrently and retries are exhausted.
+func (m *Manager[T]) Middleware(next tgbot.ContextHandler) tgbot.ContextHandler {
+	return func(c *tgbot.Context) error {
+		key, ok := m.key(c.Update)
+		if !ok {
+			return next(c)
+		}
+		a := m.acquire(key)
+		defer m.release(key, a)
+
+		for attempt := 0; ; attempt++ {
+			err := m.handle(key, a, c, next)
+			if !errors.Is(err, ErrConflict) || attempt >= m.retries {
+				return err
+			}
+		}
+	}
+}
+
+// acquire waits until no other update with the same session is being handled.
+func (m *Manager[T]) acquire(key string) *active[T] {
+	m.mu.Lock()
+	a, ok := m.active[key]
+	if !ok {
+		a = &active[T]{}
+		m.active[key] = a
+	}
+	a.refs++
+	m.mu.Unlock()
+
+	a.mu.Lock()
+	return a
+}
+
+func (m *Manager[T]) release(key string, a *active[T]) {
+	m.mu.Lock()
+	a.session = nil
+	a.refs--
+	if a.refs == 0 {
+		delete(m.active, key)
+	}
+	m.mu.Unlock()
+
+	a.mu.Unlock()
+}
+
+func (m *Manager[T]) handle(key string, a *active[T], c *tgbot.Context, next tgbot.ContextHandler) error {
+	data, version, err := m.store.Load(c, key)
+	if err != nil {
+		return err
+	}
+	s := new(T)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, s); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	a.session = s
+	m.mu.Unlock()
+
+	if err := next(c); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	// not saving unchanged sessions to avoid needless conflicts
+	if bytes.Equal(b, data) {
+		return nil
+	}
+	_, err = m.store.Save(c, key, b, version)
+	return err
+}
+
+// Of returns the session loaded by [Manager.Middleware] for the update being handled.
+// Changes made to it are saved after the handler returns.
+// Since typed handlers don't receive the whole update, it only needs the part of the update
+// used by the [KeyFunc], like objects.Update{Message: &msg}.
+// Returns nil if the update is not being handled by the middleware or has no session.
+func (m *Manager[T]) Of(upd objects.Update) *T {
+	key, ok := m.key(upd)
+	if !ok {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if a, ok := m.active[key]; ok {
+		return a.session
+	}
+	return nil
+}
+
+// Get returns the session stored with key, outside of handling an update.
+func (m *Manager[T]) Get(ctx context.Context, key string) (T, error) {
+	var s T
+	data, _, err := m.store.Load(ctx, key)
+	if err != nil || len(data) == 0 {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// Delete removes the session stored with key.
+func (m *Manager[T]) Delete(ctx context.Context, key string) error {
+	return m.store.Delete(ctx, key)
+}
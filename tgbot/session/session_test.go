@@ -0,0 +1,104 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/bigelle/gotely/objects"
+	"github.com/bigelle/gotely/tgbot"
+	"github.com/bigelle/gotely/tgbot/session"
+)
+
+type counter struct {
+	Messages int `json:"messages"`
+}
+
+func message(userId int64) objects.Update {
+	text := "hello"
+	return objects.Update{Message: &objects.Message{
+		Chat: objects.Chat{Id: userId, Type: "private"},
+		From: &objects.User{Id: userId},
+		Text: &text,
+	}}
+}
+
+func newRouter(m *session.Manager[counter]) *tgbot.Router {
+	r := tgbot.NewRouter()
+	r.Use(m.Middleware)
+	r.OnMessage(func(msg objects.Message) error {
+		m.Of(objects.Update{Message: &msg}).Messages++
+		return nil
+	})
+	return r
+}
+
+func TestSessionSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	for range 2 {
+		store, err := session.NewFileStore(path)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		m := session.New[counter](store)
+		if err := newRouter(m).OnUpdate(message(1)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	store, err := session.NewFileStore(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	got, err := session.New[counter](store).Get(context.Background(), "user:1")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if got.Messages != 2 {
+		t.Fatalf("expected 2 messages, got %d", got.Messages)
+	}
+}
+
+func TestSessionConflict(t *testing.T) {
+	store := session.NewMemoryStore()
+	// another process changes the session while the handler runs
+	interfere := func(m *session.Manager[counter]) *tgbot.Router {
+		r := tgbot.NewRouter()
+		r.Use(m.Middleware)
+		r.OnMessage(func(msg objects.Message) error {
+			m.Of(objects.Update{Message: &msg}).Messages++
+			_, version, _ := store.Load(context.Background(), "user:1")
+			_, err := store.Save(context.Background(), "user:1", []byte(`{"messages":10}`), version)
+			return err
+		})
+		return r
+	}
+
+	err := interfere(session.New[counter](store)).OnUpdate(message(1))
+	if !errors.Is(err, session.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+
+	calls := 0
+	m := session.New[counter](store, session.WithRetries(1))
+	r := tgbot.NewRouter()
+	r.Use(m.Middleware)
+	r.OnMessage(func(msg objects.Message) error {
+		calls++
+		m.Of(objects.Update{Message: &msg}).Messages++
+		if calls == 1 {
+			_, version, _ := store.Load(context.Background(), "user:1")
+			_, err := store.Save(context.Background(), "user:1", []byte(`{"messages":20}`), version)
+			return err
+		}
+		return nil
+	})
+	if err := r.OnUpdate(message(1)); err != nil {
+		t.Fatal(err.Error())
+	}
+	got, _ := m.Get(context.Background(), "user:1")
+	if calls != 2 || got.Messages != 21 {
+		t.Fatalf("expected the handler to be retried with the reloaded session, got %d calls and %d messages", calls, got.Messages)
+	}
+}
@@ -0,0 +1,139 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/bigelle/gotely/internal/atomicfile"
+)
+
+// ErrConflict is returned when a session was modified by someone else
+// since it was loaded.
+var ErrConflict = errors.New("session was modified concurrently")
+
+// Store persists sessions as JSON-encoded data with a version.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the data stored with key and its version.
+	// The version is 0 if there is no such data.
+	Load(ctx context.Context, key string) (data []byte, version uint64, err error)
+	// Save stores data with key if the version of the stored data is still version,
+	// and returns the new version. Otherwise, it returns [ErrConflict].
+	Save(ctx context.Context, key string, data []byte, version uint64) (uint64, error)
+	// Delete removes the data stored with key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+type entry struct {
+	Data    json.RawMessage `json:"data"`
+	Version uint64          `json:"version"`
+}
+
+// MemoryStore is a [Store] keeping sessions in memory.
+// Sessions are lost when the program exits.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]entry
+}
+
+// NewMemoryStore creates a new empty [MemoryStore].
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]entry)}
+}
+
+func (m *MemoryStore) Load(_ context.Context, key string) ([]byte, uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.sessions[key]
+	return e.Data, e.Version, nil
+}
+
+func (m *MemoryStore) Save(_ context.Context, key string, data []byte, version uint64) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[key].Version != version {
+		return 0, ErrConflict
+	}
+	e := entry{Data: data, Version: version + 1}
+	m.sessions[key] = e
+	return e.Version, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, key)
+	return nil
+}
+
+// FileStore is a [Store] keeping sessions in memory
+// and saving all of them to a JSON file on every change.
+// Every change rewrites the whole file, so it's meant for a moderate number of sessions.
+type FileStore struct {
+	path string
+
+	mu       sync.Mutex
+	sessions map[string]entry
+}
+
+// NewFileStore creates a new [FileStore] saving sessions to the file at path,
+// and loads the sessions saved there before, if the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+	f := &FileStore{
+		path:     path,
+		sessions: make(map[string]entry),
+	}
+	if err := atomicfile.ReadJSON(path, &f.sessions); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileStore) Load(_ context.Context, key string) ([]byte, uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e := f.sessions[key]
+	return e.Data, e.Version, nil
+}
+
+func (f *FileStore) Save(_ context.Context, key string, data []byte, version uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prev, existed := f.sessions[key]
+	if prev.Version != version {
+		return 0, ErrConflict
+	}
+	e := entry{Data: data, Version: version + 1}
+	f.sessions[key] = e
+	if err := f.save(); err != nil {
+		// the file still has the previous version
+		if existed {
+			f.sessions[key] = prev
+		} else {
+			delete(f.sessions, key)
+		}
+		return 0, err
+	}
+	return e.Version, nil
+}
+
+func (f *FileStore) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prev, existed := f.sessions[key]
+	if !existed {
+		return nil
+	}
+	delete(f.sessions, key)
+	if err := f.save(); err != nil {
+		f.sessions[key] = prev
+		return err
+	}
+	return nil
+}
+
+func (f *FileStore) save() error {
+	return atomicfile.WriteJSON(f.path, f.sessions)
+}
@@ -0,0 +1,91 @@
+package tgbot
+
+import "github.com/bigelle/gotely/objects"
+
+// MessageOf returns the message the update is about:
+// a new or edited message, channel post or business message,
+// or the accessible message a callback query was sent from.
+// Returns nil if there is no such message.
+func MessageOf(upd objects.Update) *objects.Message {
+	switch {
+	case upd.Message != nil:
+		return upd.Message
+	case upd.EditedMessage != nil:
+		return upd.EditedMessage
+	case upd.ChannelPost != nil:
+		return upd.ChannelPost
+	case upd.EditedChannelPost != nil:
+		return upd.EditedChannelPost
+	case upd.BusinessMessage != nil:
+		return upd.BusinessMessage
+	case upd.EditedBusinessMessage != nil:
+		return upd.EditedBusinessMessage
+	case upd.CallbackQuery != nil && upd.CallbackQuery.Message != nil:
+		return upd.CallbackQuery.Message.Accessible
+	}
+	return nil
+}
+
+// ChatOf returns the chat the update came from.
+// Returns nil if the update is not related to a chat, for example an inline query.
+func ChatOf(upd objects.Update) *objects.Chat {
+	if msg := MessageOf(upd); msg != nil {
+		return &msg.Chat
+	}
+	switch {
+	case upd.CallbackQuery != nil && upd.CallbackQuery.Message != nil && upd.CallbackQuery.Message.Inaccessible != nil:
+		return &upd.CallbackQuery.Message.Inaccessible.Chat
+	case upd.DeletedBusinessMessage != nil:
+		return &upd.DeletedBusinessMessage.Chat
+	case upd.MessageReaction != nil:
+		return &upd.MessageReaction.Chat
+	case upd.MessageReactionCount != nil:
+		return &upd.MessageReactionCount.Chat
+	case upd.MyChatMember != nil:
+		return &upd.MyChatMember.Chat
+	case upd.ChatMember != nil:
+		return &upd.ChatMember.Chat
+	case upd.ChatJoinRequest != nil:
+		return &upd.ChatJoinRequest.Chat
+	case upd.ChatBoost != nil:
+		return &upd.ChatBoost.Chat
+	case upd.RemovedChatBoost != nil:
+		return &upd.RemovedChatBoost.Chat
+	}
+	return nil
+}
+
+// SenderOf returns the user who caused the update.
+// Returns nil if the update has no such user, for example a channel post.
+func SenderOf(upd objects.Update) *objects.User {
+	switch {
+	case upd.CallbackQuery != nil:
+		return &upd.CallbackQuery.From
+	case upd.InlineQuery != nil:
+		return &upd.InlineQuery.From
+	case upd.ChosenInlineQuery != nil:
+		return &upd.ChosenInlineQuery.From
+	case upd.ShippingQuery != nil:
+		return &upd.ShippingQuery.From
+	case upd.PreCheckoutQuery != nil:
+		return upd.PreCheckoutQuery.From
+	case upd.PurchasedPaidMedia != nil:
+		return &upd.PurchasedPaidMedia.User
+	case upd.PollAnswer != nil:
+		return upd.PollAnswer.User
+	case upd.BusinessConnection != nil:
+		return &upd.BusinessConnection.User
+	case upd.MessageReaction != nil:
+		return upd.MessageReaction.User
+	case upd.MyChatMember != nil:
+		return &upd.MyChatMember.From
+	case upd.ChatMember != nil:
+		return &upd.ChatMember.From
+	case upd.ChatJoinRequest != nil:
+		return &upd.ChatJoinRequest.User
+	}
+	if msg := MessageOf(upd); msg != nil {
+		return msg.From
+	}
+	return nil
+}
@@ -2,11 +2,15 @@ package webhook
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/bigelle/gotely"
@@ -15,11 +19,23 @@ import (
 )
 
 // WebhookBot creates a simple webhook server
-// that responds to updates from the Telegram Bot API.
+// that responds to updates from the Telegram Bot API
+// using the OnUpdate function defined in [tgbot.Bot],
+// or OnUpdateContext if the bot implements [tgbot.ContextBot].
 type WebhookBot struct {
 	Bot tgbot.Bot
 
+	client          *gotely.Client
 	s               *http.Server
+	ctx             context.Context
+	cancel          context.CancelFunc
+	workers         *workers
+	workingPool     uint
+	ordered         bool
+	async           bool
+	queueSize       int
+	onError         func(objects.Update, error)
+	err             error // returned by Start
 	path            string
 	addr            string
 	middleware      []func(next http.Handler) http.Handler
@@ -29,6 +45,10 @@ type WebhookBot struct {
 	certFile string
 	keyFile  string
 	useTLS   bool
+
+	secretToken   string
+	setWebhook    *SetWebhook
+	deleteWebhook *DeleteWebhook
 }
 
 // New creates a new instance of [WebhookBot] using the specified options.
@@ -45,6 +65,27 @@ func New(bot tgbot.Bot, opts ...Option) WebhookBot {
 	for _, opt := range opts {
 		opt(&b)
 	}
+	if b.client == nil {
+		b.client = tgbot.NewClient(bot)
+	}
+	if b.setWebhook != nil && b.setWebhook.SecretToken != nil && *b.setWebhook.SecretToken != "" {
+		// Telegram sends the token set with the webhook, so it's checked the same way
+		switch token := *b.setWebhook.SecretToken; {
+		case b.secretToken == "":
+			b.secretToken = token
+		case b.secretToken != token:
+			b.err = errors.New("the secret token passed to WithSecretToken differs from the one passed to WithSetWebhook")
+		}
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	if b.onError == nil {
+		b.onError = func(upd objects.Update, err error) {
+			b.l.Error("error while handling an update", "err", err, "update ID", upd.UpdateId)
+		}
+	}
+	if b.ordered || b.async || b.workingPool > 0 {
+		b.workers = &workers{}
+	}
 
 	if b.s == nil {
 		mux := http.NewServeMux()
@@ -61,6 +102,11 @@ func New(bot tgbot.Bot, opts ...Option) WebhookBot {
 	return b
 }
 
+// Client returns the [gotely.Client] used by the bot to send requests to the Telegram Bot API.
+func (b WebhookBot) Client() *gotely.Client {
+	return b.client
+}
+
 // Use adds middleware that wraps the bot's update handler.
 func (b *WebhookBot) Use(m ...func(http.Handler) http.Handler) {
 	b.middleware = append(b.middleware, m...)
@@ -72,23 +118,86 @@ func (b *WebhookBot) SetMiddleware(m []func(http.Handler) http.Handler) {
 }
 
 // Start launches the bot's [http.Server].
+// If the bot was created with [WithSetWebhook], the webhook is set once the server is listening.
+// The worker pool set with [WithWorkingPool], [WithAsync] or [WithOrderedProcessing] is started with the server.
 func (b *WebhookBot) Start() error {
+	if b.err != nil {
+		return b.err
+	}
+	ln, err := net.Listen("tcp", b.addr)
+	if err != nil {
+		return err
+	}
 	b.l.Info("webhook server is listening and serving on", "addr", b.addr, "path", b.path)
+
+	if b.setWebhook != nil {
+		sw := *b.setWebhook
+		if sw.SecretToken == nil && b.secretToken != "" {
+			sw.SecretToken = &b.secretToken
+		}
+		if _, err := gotely.Call(b.ctx, b.client, &sw); err != nil {
+			ln.Close()
+			return fmt.Errorf("can't set the webhook: %w", err)
+		}
+		b.l.Info("webhook is set", "url", sw.Url)
+	}
+
+	if b.workers != nil {
+		if err := b.workers.start(b.newPool); err != nil {
+			ln.Close()
+			return err
+		}
+	}
+
 	if b.useTLS {
 		b.l.Debug("starting HTTPS server with TLS", "cert", b.certFile, "key", b.keyFile)
-		return b.s.ListenAndServeTLS(b.certFile, b.keyFile)
+		return b.s.ServeTLS(ln, b.certFile, b.keyFile)
 	}
-	return b.s.ListenAndServe()
+	return b.s.Serve(ln)
 }
 
 // Stop shuts down the bot's [http.Server], allowing the time specified in the bot's settings for active requests to complete.
+// The [tgbot.Context] of the updates still being handled is canceled when that time is up,
+// and the updates still queued are dropped.
 func (b WebhookBot) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), b.shutdownTimeout)
 	defer cancel()
-	return b.s.Shutdown(ctx)
+	defer b.cancel()
+	err := b.s.Shutdown(ctx)
+	if pool := b.workers.stop(); pool != nil {
+		// waiting for the queued updates, but not longer than the shutdown timeout
+		closed := make(chan struct{})
+		go func() {
+			pool.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-ctx.Done():
+			b.cancel()
+			<-closed
+		}
+	}
+	if b.deleteWebhook != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, er := gotely.Call(ctx, b.client, *b.deleteWebhook); er != nil {
+			err = errors.Join(err, fmt.Errorf("can't delete the webhook: %w", er))
+		}
+	}
+	return err
 }
 
 func (b *WebhookBot) handleFunc(w http.ResponseWriter, r *http.Request) {
+	if b.secretToken != "" {
+		token := r.Header.Get(SecretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(b.secretToken)) != 1 {
+			b.l.Warn("request with an invalid secret token", "remote_addr", r.RemoteAddr)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
 	var upd objects.Update
 	if err := gotely.DecodeJSON(r.Body, &upd); err != nil {
 		b.l.Error("can't read JSON", "err", err)
@@ -96,7 +205,30 @@ func (b *WebhookBot) handleFunc(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := b.Bot.OnUpdate(upd)
+	if b.async {
+		err := tgbot.ErrPoolClosed
+		if pool := b.workers.get(); pool != nil {
+			err = pool.Submit(r.Context(), upd, func(err error) {
+				if err != nil {
+					b.onError(upd, err)
+				}
+			})
+		}
+		if err != nil {
+			b.l.Error("can't queue an update", "err", err, "update ID", upd.UpdateId)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var err error
+	if b.workers != nil {
+		err = b.submit(r.Context(), upd)
+	} else {
+		err = b.handle(upd)
+	}
 	if err != nil {
 		var validationErr gotely.ErrFailedValidation
 		if errors.As(err, &validationErr) {
@@ -134,8 +266,183 @@ func (b *WebhookBot) handleFunc(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// submit passes upd to the bot on the worker pool and waits until it's handled.
+func (b *WebhookBot) submit(ctx context.Context, upd objects.Update) error {
+	pool := b.workers.get()
+	if pool == nil {
+		return tgbot.ErrPoolClosed
+	}
+	done := make(chan error, 1)
+	if err := pool.Submit(ctx, upd, func(err error) { done <- err }); err != nil {
+		return err
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handle passes upd to the bot, with a [tgbot.Context] canceled on Stop if the bot implements [tgbot.ContextBot].
+// The updates left in the queue when the shutdown timeout is up are dropped.
+func (b *WebhookBot) handle(upd objects.Update) error {
+	if err := b.ctx.Err(); err != nil {
+		return err
+	}
+	if cb, ok := b.Bot.(tgbot.ContextBot); ok {
+		return cb.OnUpdateContext(tgbot.NewContext(b.ctx, upd, b.client, b.l))
+	}
+	return b.Bot.OnUpdate(upd)
+}
+
+// newPool creates the worker pool handling the updates with the bot's settings.
+func (b *WebhookBot) newPool() *tgbot.WorkerPool {
+	opts := []tgbot.PoolOption{tgbot.WithQueueSize(b.queueSize)}
+	if b.ordered {
+		opts = append(opts, tgbot.Ordered())
+	}
+	n := int(b.workingPool)
+	if n == 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	return tgbot.NewWorkerPool(n, b.handle, opts...)
+}
+
+// workers holds the worker pool of the bot.
+// It's shared by the copies of [WebhookBot], since the pool is started by Start
+// and the request handler is bound to the bot in New.
+type workers struct {
+	mu      sync.Mutex
+	pool    *tgbot.WorkerPool
+	stopped bool
+}
+
+// start creates the pool with newPool, unless it's already started or the bot was stopped.
+func (w *workers) start(newPool func() *tgbot.WorkerPool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return tgbot.ErrPoolClosed
+	}
+	if w.pool == nil {
+		w.pool = newPool()
+	}
+	return nil
+}
+
+// get returns the started pool, or nil if there is none.
+func (w *workers) get() *tgbot.WorkerPool {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.pool
+}
+
+// stop prevents the pool from being started and returns it, if it was started, so it can be closed.
+func (w *workers) stop() *tgbot.WorkerPool {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	return w.pool
+}
+
 type Option func(*WebhookBot)
 
+// WithClient sets the [gotely.Client] used to send requests to the Telegram Bot API.
+// Use it to share a single client between several bots.
+// Defaults to a client created with [tgbot.NewClient].
+func WithClient(c *gotely.Client) Option {
+	return func(wb *WebhookBot) {
+		wb.client = c
+	}
+}
+
+// SecretTokenHeader is the header containing the secret token in every webhook request.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// WithSecretToken makes the bot reject the requests that don't contain token in the [SecretTokenHeader],
+// responding with 401 Unauthorized.
+// The token must be the same as the SecretToken passed to [SetWebhook].
+// It's also used when setting the webhook with [WithSetWebhook], unless another one is specified there.
+func WithSecretToken(token string) Option {
+	return func(wb *WebhookBot) {
+		wb.secretToken = token
+	}
+}
+
+// WithSetWebhook makes the bot set the webhook with s when it's started,
+// once its server is listening for requests.
+// If s has a SecretToken, the bot rejects the requests without it, as with [WithSecretToken].
+func WithSetWebhook(s SetWebhook) Option {
+	return func(wb *WebhookBot) {
+		wb.setWebhook = &s
+	}
+}
+
+// WithDeleteOnStop makes the bot delete the webhook when it's stopped,
+// dropping the pending updates if dropPendingUpdates is true.
+func WithDeleteOnStop(dropPendingUpdates bool) Option {
+	return func(wb *WebhookBot) {
+		wb.deleteWebhook = &DeleteWebhook{DropPendingUpdates: &dropPendingUpdates}
+	}
+}
+
+// WithWorkingPool makes the bot handle updates on a pool of p workers,
+// instead of the goroutine serving the webhook request.
+// The response is still sent after the update is handled, unless [WithAsync] is used.
+func WithWorkingPool(p uint) Option {
+	return func(wb *WebhookBot) {
+		wb.workingPool = p
+	}
+}
+
+// WithAsync makes the bot respond to webhook requests as soon as the update is queued,
+// and handle the updates on the pool set with [WithWorkingPool],
+// or on a pool of [runtime.GOMAXPROCS] workers if there is none.
+// When the queue is full, the response is delayed until there is room in it,
+// slowing down the delivery of updates by the Telegram Bot API.
+// Since the errors returned by the bot can't be reported in the response,
+// they are passed to the function set with [WithErrorHandler].
+func WithAsync() Option {
+	return func(wb *WebhookBot) {
+		wb.async = true
+	}
+}
+
+// WithQueueSize sets how many updates can wait to be handled by the bot's working pool.
+// See [tgbot.WithQueueSize].
+func WithQueueSize(n int) Option {
+	return func(wb *WebhookBot) {
+		wb.queueSize = n
+	}
+}
+
+// WithErrorHandler sets the function called with the errors returned by the bot
+// for the updates handled with [WithAsync].
+// Defaults to logging the error.
+func WithErrorHandler(f func(objects.Update, error)) Option {
+	return func(wb *WebhookBot) {
+		wb.onError = f
+	}
+}
+
+// WithOrderedProcessing makes the bot handle the updates from the same chat or user
+// one after another, in the order they were received, while still handling different chats concurrently.
+// The updates are handled on the pool set with [WithWorkingPool],
+// or on a pool of [runtime.GOMAXPROCS] workers if there is none.
+// See [tgbot.Ordered].
+func WithOrderedProcessing() Option {
+	return func(wb *WebhookBot) {
+		wb.ordered = true
+	}
+}
+
 // WithReadsTimeout sets the timeout for the bot's [http.Server].
 func WithReadTimeout(t time.Duration) Option {
 	return func(wb *WebhookBot) {
@@ -2,7 +2,9 @@ package webhook_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -97,3 +99,194 @@ func TestNoErr(t *testing.T) {
 		t.Fatalf("expected status 200, got %d", resp.StatusCode)
 	}
 }
+
+// routerBot handles updates with a router, receiving a [tgbot.Context].
+type routerBot struct {
+	token string
+	tgbot.DefaultBot
+	*tgbot.Router
+}
+
+func (b routerBot) Token() string {
+	return b.token
+}
+
+func (b routerBot) Client() *http.Client {
+	return TestNoErrBot{}.Client()
+}
+
+func TestContextBot(t *testing.T) {
+	replied := make(chan string, 1)
+	r := tgbot.NewRouter()
+	r.HandleContext(tgbot.Text, func(c *tgbot.Context) error {
+		msg, err := c.Reply(*c.Update.Message.Text)
+		if err == nil {
+			replied <- fmt.Sprint(msg.MessageId)
+		}
+		return err
+	})
+	bot := routerBot{token: "MOCK_TOKEN", Router: r}
+	hook := webhook.New(bot, webhook.WithAddress(":8083"))
+	defer hook.Stop()
+	go hook.Start()
+
+	time.Sleep(100 * time.Millisecond)
+
+	resp, err := http.Post("http://localhost:8083/webhook", "application/json", bytes.NewBuffer([]byte(`{"update_id": 123, "message": {"message_id": 1, "text": "hello", "from": {"id": 42}, "chat": {"id": 42, "type": "private"}}}`)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if id := <-replied; id != "42" {
+		t.Fatalf("expected the reply to be sent with the client of the bot, got message %s", id)
+	}
+}
+
+func TestSecretToken(t *testing.T) {
+	set := make(chan *webhook.SetWebhook, 1)
+	var deleted bool
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			switch m := body.(type) {
+			case *webhook.SetWebhook:
+				set <- m
+			case webhook.DeleteWebhook:
+				deleted = true
+			}
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`true`)}, nil
+		}
+	}))
+
+	r := tgbot.NewRouter()
+	bot := routerBot{token: "MOCK_TOKEN", Router: r}
+	hook := webhook.New(bot,
+		webhook.WithClient(client),
+		webhook.WithAddress(":8081"),
+		webhook.WithSecretToken("secret"),
+		webhook.WithSetWebhook(webhook.SetWebhook{Url: "https://example.com/webhook"}),
+		webhook.WithDeleteOnStop(false),
+	)
+	go hook.Start()
+
+	sw := <-set
+	if sw.SecretToken == nil || *sw.SecretToken != "secret" {
+		t.Fatalf("expected the webhook to be set with the secret token, got %v", sw.SecretToken)
+	}
+
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "secret": http.StatusOK} {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8081/webhook", bytes.NewBufferString(`{"update_id": 1}`))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if token != "" {
+			req.Header.Set(webhook.SecretTokenHeader, token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("expected status %d with token %q, got %d", status, token, resp.StatusCode)
+		}
+	}
+
+	if err := hook.Stop(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !deleted {
+		t.Fatal("expected the webhook to be deleted on Stop")
+	}
+}
+
+func TestSetWebhookSecretToken(t *testing.T) {
+	set := make(chan struct{}, 1)
+	client := gotely.NewClient("MOCK_TOKEN", gotely.WithInterceptors(func(next gotely.Invoker) gotely.Invoker {
+		return func(ctx context.Context, endpoint string, body gotely.Method) (*gotely.ApiResponse, error) {
+			if _, ok := body.(*webhook.SetWebhook); ok {
+				set <- struct{}{}
+			}
+			return &gotely.ApiResponse{Ok: true, Result: json.RawMessage(`true`)}, nil
+		}
+	}))
+	secret := "secret"
+
+	r := tgbot.NewRouter()
+	bot := routerBot{token: "MOCK_TOKEN", Router: r}
+	hook := webhook.New(bot,
+		webhook.WithClient(client),
+		webhook.WithAddress(":8084"),
+		webhook.WithSetWebhook(webhook.SetWebhook{Url: "https://example.com/webhook", SecretToken: &secret}),
+	)
+	go hook.Start()
+	defer hook.Stop()
+	<-set
+
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "secret": http.StatusOK} {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8084/webhook", bytes.NewBufferString(`{"update_id": 1}`))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if token != "" {
+			req.Header.Set(webhook.SecretTokenHeader, token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("expected status %d with token %q, got %d", status, token, resp.StatusCode)
+		}
+	}
+
+	other := webhook.New(bot,
+		webhook.WithClient(client),
+		webhook.WithSecretToken("other"),
+		webhook.WithSetWebhook(webhook.SetWebhook{Url: "https://example.com/webhook", SecretToken: &secret}),
+	)
+	if err := other.Start(); err == nil {
+		t.Fatal("expected an error when the secret tokens differ")
+	}
+}
+
+func TestAsync(t *testing.T) {
+	release := make(chan struct{})
+	failed := make(chan int, 1)
+
+	r := tgbot.NewRouter()
+	r.Fallback(func(upd objects.Update) error {
+		<-release
+		return errors.New("can't handle the update")
+	})
+	bot := routerBot{token: "MOCK_TOKEN", Router: r}
+	hook := webhook.New(bot,
+		webhook.WithAddress(":8082"),
+		webhook.WithAsync(),
+		webhook.WithErrorHandler(func(upd objects.Update, err error) {
+			failed <- upd.UpdateId
+		}),
+	)
+	go hook.Start()
+	defer hook.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	// the response doesn't wait for the handler
+	resp, err := http.Post("http://localhost:8082/webhook", "application/json", bytes.NewBufferString(`{"update_id": 7}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	close(release)
+	if id := <-failed; id != 7 {
+		t.Fatalf("expected the error of update 7 to be reported, got %d", id)
+	}
+}
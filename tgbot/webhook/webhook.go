@@ -20,6 +20,8 @@ import (
 // you can specify secret data in the parameter secret_token.
 // If specified, the request will contain a header “X-Telegram-Bot-Api-Secret-Token” with the secret token as content
 type SetWebhook struct {
+	gotely.Returns[bool]
+
 	// HTTPS URL to send updates to. Use an empty string to remove webhook integration
 	Url string `json:"url"`
 	// Upload your public key certificate so that the root certificate in use can be checked.
@@ -63,12 +65,30 @@ func (s SetWebhook) Validate() error {
 			err = append(err, er)
 		}
 	}
+	if s.MaxConnections != nil && (*s.MaxConnections < 1 || *s.MaxConnections > 100) {
+		err = append(err, fmt.Errorf("max_connections must be between 1 and 100"))
+	}
+	if s.SecretToken != nil && !validSecretToken(*s.SecretToken) {
+		err = append(err, fmt.Errorf("secret_token must be 1-256 characters long and contain only A-Z, a-z, 0-9, _ and -"))
+	}
 	if len(err) > 0 {
 		return err
 	}
 	return nil
 }
 
+func validSecretToken(token string) bool {
+	if len(token) < 1 || len(token) > 256 {
+		return false
+	}
+	for _, c := range token {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *SetWebhook) Reader() io.Reader {
 	pr, pw := io.Pipe()
 	mw := multipart.NewWriter(pw)
@@ -95,7 +115,7 @@ func (s *SetWebhook) Reader() io.Reader {
 			}
 		}
 		if s.MaxConnections != nil {
-			if err := mw.WriteField("max_connections", fmt.Sprint(s.MaxConnections)); err != nil {
+			if err := mw.WriteField("max_connections", fmt.Sprint(*s.MaxConnections)); err != nil {
 				pw.CloseWithError(err)
 				return
 			}
@@ -107,7 +127,7 @@ func (s *SetWebhook) Reader() io.Reader {
 			}
 		}
 		if s.DropPendingUpdates != nil {
-			if err := mw.WriteField("drop_pending_updates", fmt.Sprint(s.DropPendingUpdates)); err != nil {
+			if err := mw.WriteField("drop_pending_updates", fmt.Sprint(*s.DropPendingUpdates)); err != nil {
 				pw.CloseWithError(err)
 				return
 			}
@@ -133,6 +153,8 @@ func (s SetWebhook) ContentType() string {
 // Use this method to remove webhook integration if you decide to switch back to getUpdates.
 // Returns True on success.
 type DeleteWebhook struct {
+	gotely.Returns[bool]
+
 	// Pass True to drop all pending updates
 	DropPendingUpdates *bool `json:"drop_pending_updates,omitempty"`
 }
@@ -157,7 +179,9 @@ func (d DeleteWebhook) ContentType() string {
 // Requires no parameters.
 // On success, returns a [WebhookInfo] object.
 // If the bot is using [longpolling.GetUpdates}, will return an object with the url field empty.
-type GetWebhookInfo struct{}
+type GetWebhookInfo struct {
+	gotely.Returns[WebhookInfo]
+}
 
 func (g GetWebhookInfo) Validate() error {
 	return nil
@@ -84,7 +84,7 @@ func (e ErrTelegramAPIFailedRequest) Is(target error) bool {
 }
 
 func (e ErrTelegramAPIFailedRequest) Unwrap() error {
-	return e
+	return nil
 }
 
 // DecodeExactField reads the contents of source, searches for the specified field